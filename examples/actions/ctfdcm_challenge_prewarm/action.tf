@@ -0,0 +1,6 @@
+action "ctfdcm_challenge_prewarm" "http" {
+  config {
+    challenge_id = ctfdcm_challenge_dynamiciac.http.id
+    timeout      = 300
+  }
+}
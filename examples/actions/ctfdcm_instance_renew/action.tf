@@ -0,0 +1,6 @@
+action "ctfdcm_instance_renew" "ist" {
+  config {
+    challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+    source_id    = ctfd_team.ctfer.id
+  }
+}
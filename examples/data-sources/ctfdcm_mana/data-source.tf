@@ -0,0 +1,7 @@
+data "ctfdcm_mana" "sponsor" {
+  source_id = ctfd_team.sponsor.id
+}
+
+output "sponsor_remaining_mana" {
+  value = data.ctfdcm_mana.sponsor.remaining
+}
@@ -0,0 +1,4 @@
+ephemeral "ctfdcm_instance" "smoke" {
+  challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+  source_id    = ctfd_team.ci.id
+}
@@ -0,0 +1,13 @@
+ephemeral "ctfdcm_token" "ci" {
+  username    = var.ctfd_admin_username
+  password    = var.ctfd_admin_password
+  description = "CI pipeline"
+}
+
+data "http" "scoreboard" {
+  url = "https://my-ctfd.lan/api/v1/scoreboard"
+  request_headers = {
+    Authorization = "Token ${ephemeral.ctfdcm_token.ci.token}"
+    Content-Type  = "application/json"
+  }
+}
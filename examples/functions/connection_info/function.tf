@@ -0,0 +1,3 @@
+output "connection_info" {
+  value = provider::ctfdcm::connection_info("https://{{ .Identity }}.my-ctf.lan", "a0b1c2d3") # https://a0b1c2d3.my-ctf.lan
+}
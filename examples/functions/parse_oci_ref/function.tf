@@ -0,0 +1,7 @@
+locals {
+  scenario = provider::ctfdcm::parse_oci_ref("localhost:5000/some/scenario:v0.1.0")
+}
+
+output "scenario_tag" {
+  value = local.scenario.tag # v0.1.0
+}
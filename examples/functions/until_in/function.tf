@@ -0,0 +1,11 @@
+resource "ctfdcm_challenge_dynamiciac" "http" {
+  name        = "My Challenge"
+  category    = "misc"
+  description = "..."
+  value       = 500
+  decay       = 100
+  minimum     = 50
+
+  scenario = "localhost:5000/some/scenario:v0.1.0"
+  until    = provider::ctfdcm::until_in(var.ctf_end, "-30m")
+}
@@ -0,0 +1,7 @@
+list "ctfdcm_challenge_dynamiciac" "web" {
+  provider = ctfdcm
+
+  config {
+    category = "web"
+  }
+}
@@ -0,0 +1,7 @@
+list "ctfdcm_instance" "chall" {
+  provider = ctfdcm
+
+  config {
+    challenge_id = "1"
+  }
+}
@@ -0,0 +1,21 @@
+# The challenge.yml could look like the following, with the scenario directory
+# pushed to the provider scenario_registry.
+#
+#   name: My Challenge
+#   category: misc
+#   description: ...
+#   value: 500
+#   type: dynamic
+#   extra:
+#     decay: 100
+#     minimum: 50
+#     mana_cost: 1
+#     scenario: ./scenario
+#     timeout: 600
+#   flags:
+#     - CTF{some_flag}
+#   files:
+#     - dist/note.txt
+resource "ctfdcm_challenge_bundle" "http" {
+  directory = "${path.module}/challenges/http"
+}
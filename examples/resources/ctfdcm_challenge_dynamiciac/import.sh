@@ -0,0 +1,2 @@
+# Challenge can be imported using its identifier
+terraform import ctfdcm_challenge_dynamiciac.http 1
@@ -21,9 +21,20 @@ resource "ctfdcm_challenge_dynamiciac" "http" {
     "misc",
     "basic"
   ]
-}
 
-resource "ctfd_flag" "http_flag" {
-  challenge_id = ctfdcm_challenge_dynamiciac.http.id
-  content      = "CTF{some_flag}"
+  flags = [{
+    content = "CTF{some_flag}"
+  }]
+  hints = [{
+    content = "Some small hint"
+    cost    = 10
+    }, {
+    content      = "Some bigger hint"
+    cost         = 50
+    requirements = [0]
+  }]
+  files = [{
+    name           = "note.txt"
+    content_base64 = base64encode("Some note to start with.")
+  }]
 }
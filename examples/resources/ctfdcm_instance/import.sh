@@ -0,0 +1,2 @@
+# Instance can be imported using its challenge and source identifiers
+terraform import ctfdcm_instance.ist 1/2
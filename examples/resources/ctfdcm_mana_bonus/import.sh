@@ -0,0 +1,2 @@
+# Mana bonus can be imported using its source identifier
+terraform import ctfdcm_mana_bonus.sponsor 1
@@ -0,0 +1,4 @@
+resource "ctfdcm_mana_bonus" "sponsor" {
+  source_id = ctfd_team.sponsor.id
+  bonus     = 5
+}
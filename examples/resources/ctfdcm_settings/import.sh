@@ -0,0 +1,2 @@
+# Settings can be imported whatever the identifier, as there is only one
+terraform import ctfdcm_settings.settings settings
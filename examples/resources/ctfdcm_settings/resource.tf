@@ -0,0 +1,5 @@
+resource "ctfdcm_settings" "settings" {
+  chall_manager_api_url = "http://chall-manager:8080"
+  mana_total            = 10
+  instance_timeout      = 3600
+}
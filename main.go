@@ -4,9 +4,12 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 // If you do not have terraform installed, you can remove the formatting command, but its suggested to
@@ -22,21 +25,31 @@ var (
 )
 
 func main() {
+	// The export mode dumps the CTFd dynamic_iac challenges rather than serving the provider
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		// Exit once export returned, such that telemetry is shut down before
+		if err := export(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/ctfer-io/ctfdcm",
-		Debug:   debug,
+	opts := []tf6server.ServeOpt{}
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
 	ctx := context.Background()
 
+	// Telemetry issues must not prevent from serving, out remains usable
 	out, err := provider.SetupOTelSDK(ctx, version)
 	if err != nil {
-		log.Fatal(err)
+		log.Printf("Error setting up OpenTelemetry: %v", err)
 	}
 	defer func() {
 		if err := out.Shutdown(ctx); err != nil {
@@ -44,7 +57,33 @@ func main() {
 		}
 	}()
 
-	if err := providerserver.Serve(ctx, provider.New(version, out.TracerProvider), opts); err != nil {
+	// The provider server is wrapped to record the Terraform operations metrics
+	server := providerserver.NewProtocol6(provider.New(version, out.TracerProvider)())
+	if err := tf6server.Serve("registry.terraform.io/ctfer-io/ctfdcm", func() tfprotov6.ProviderServer {
+		return provider.NewMetricsProviderServer(server())
+	}, opts...); err != nil {
 		log.Fatal(err)
 	}
 }
+
+func export(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", provider.ExportFormatHCL, "the format to export challenges to, either hcl (Terraform configuration with import blocks) or yaml (ctfcli challenge.yml bundles)")
+	output := fs.String("output", ".", "the directory to export challenges to")
+	_ = fs.Parse(args)
+
+	ctx := context.Background()
+
+	// Telemetry issues must not prevent the export, out remains usable
+	out, err := provider.SetupOTelSDK(ctx, version)
+	if err != nil {
+		log.Printf("Error setting up OpenTelemetry: %v", err)
+	}
+	defer func() {
+		if err := out.Shutdown(ctx); err != nil {
+			log.Printf("Error shutting down tracer provider: %v", err)
+		}
+	}()
+
+	return provider.Export(ctx, *format, *output, provider.WithTracerProvider(out.TracerProvider))
+}
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+const (
+	// challengeYAMLFile is the file describing a challenge in a ctfcli bundle.
+	challengeYAMLFile = "challenge.yml"
+)
+
+// ChallengeYAML is a challenge in the ctfcli challenge.yml format.
+// The CTFd-Chall-Manager plugin attributes are set under extra, along with the
+// dynamic value ones.
+type ChallengeYAML struct {
+	Name           string                     `yaml:"name"`
+	Category       string                     `yaml:"category"`
+	Description    string                     `yaml:"description"`
+	Attribution    *string                    `yaml:"attribution,omitempty"`
+	ConnectionInfo *string                    `yaml:"connection_info,omitempty"`
+	Attempts       *int                       `yaml:"attempts,omitempty"`
+	Value          *int                       `yaml:"value,omitempty"`
+	Type           string                     `yaml:"type"`
+	State          string                     `yaml:"state,omitempty"`
+	Next           string                     `yaml:"next,omitempty"`
+	Extra          ChallengeYAMLExtra         `yaml:"extra"`
+	Flags          []ChallengeYAMLFlag        `yaml:"flags,omitempty"`
+	Tags           []string                   `yaml:"tags,omitempty"`
+	Topics         []string                   `yaml:"topics,omitempty"`
+	Hints          []ChallengeYAMLHint        `yaml:"hints,omitempty"`
+	Files          []string                   `yaml:"files,omitempty"`
+	Requirements   *ChallengeYAMLRequirements `yaml:"requirements,omitempty"`
+}
+
+type ChallengeYAMLExtra struct {
+	Initial  *int    `yaml:"initial,omitempty"`
+	Decay    *int    `yaml:"decay,omitempty"`
+	Minimum  *int    `yaml:"minimum,omitempty"`
+	Function *string `yaml:"function,omitempty"`
+
+	// CTFd-Chall-Manager plugin
+	Shared        bool              `yaml:"shared,omitempty"`
+	DestroyOnFlag bool              `yaml:"destroy_on_flag,omitempty"`
+	ManaCost      int               `yaml:"mana_cost,omitempty"`
+	Scenario      string            `yaml:"scenario"`
+	Timeout       *int              `yaml:"timeout,omitempty"`
+	Until         *string           `yaml:"until,omitempty"`
+	Additional    map[string]string `yaml:"additional,omitempty"`
+	Min           int               `yaml:"min,omitempty"`
+	Max           int               `yaml:"max,omitempty"`
+	FlagMode      string            `yaml:"flag_mode,omitempty"`
+}
+
+// ChallengeYAMLFlag is a flag, either given as its content only or detailed.
+type ChallengeYAMLFlag struct {
+	Type    string `yaml:"type,omitempty"`
+	Content string `yaml:"content"`
+	Data    string `yaml:"data,omitempty"`
+}
+
+func (f *ChallengeYAMLFlag) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f.Content = node.Value
+		return nil
+	}
+	type flag ChallengeYAMLFlag
+	return node.Decode((*flag)(f))
+}
+
+// ChallengeYAMLHint is a hint, either given as its content only or detailed.
+type ChallengeYAMLHint struct {
+	Content string `yaml:"content"`
+	Cost    int    `yaml:"cost,omitempty"`
+}
+
+func (h *ChallengeYAMLHint) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		h.Content = node.Value
+		return nil
+	}
+	type hint ChallengeYAMLHint
+	return node.Decode((*hint)(h))
+}
+
+// ChallengeYAMLRequirements are the challenges to solve before, by name or identifier.
+// They are either given as a list, or along with whether to anonymize the challenge.
+type ChallengeYAMLRequirements struct {
+	Prerequisites []string `yaml:"prerequisites"`
+	Anonymize     bool     `yaml:"anonymize,omitempty"`
+}
+
+func (r *ChallengeYAMLRequirements) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&r.Prerequisites)
+	}
+	type requirements ChallengeYAMLRequirements
+	return node.Decode((*requirements)(r))
+}
+
+// readChallengeYAML reads and validates the challenge.yml of a bundle directory.
+func readChallengeYAML(dir string) (*ChallengeYAML, error) {
+	b, err := os.ReadFile(filepath.Join(dir, challengeYAMLFile))
+	if err != nil {
+		return nil, err
+	}
+	cy := &ChallengeYAML{}
+	if err := yaml.Unmarshal(b, cy); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", challengeYAMLFile, err)
+	}
+
+	// ctfcli bundles of dynamic challenges are converted to dynamic_iac ones
+	if !slices.Contains([]string{"", "dynamic", "dynamic_iac"}, cy.Type) {
+		return nil, fmt.Errorf("challenge type must be dynamic or dynamic_iac, got %s", cy.Type)
+	}
+	for _, req := range []struct {
+		name string
+		set  bool
+	}{
+		{"name", cy.Name != ""},
+		{"category", cy.Category != ""},
+		{"value", cy.Value != nil || cy.Extra.Initial != nil},
+		{"extra.decay", cy.Extra.Decay != nil},
+		{"extra.minimum", cy.Extra.Minimum != nil},
+		{"extra.scenario", cy.Extra.Scenario != ""},
+	} {
+		if !req.set {
+			return nil, fmt.Errorf("%s is required", req.name)
+		}
+	}
+	for _, file := range cy.Files {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			return nil, fmt.Errorf("file %s: %w", file, err)
+		}
+	}
+	return cy, nil
+}
+
+// scenarioDir returns the scenario directory of the bundle, if it is one rather
+// than an OCI reference.
+func (cy *ChallengeYAML) scenarioDir(dir string) (string, bool) {
+	sdir := filepath.Join(dir, cy.Extra.Scenario)
+	fi, err := os.Stat(sdir)
+	return sdir, err == nil && fi.IsDir()
+}
+
+var nonRepositoryChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// scenarioRepository returns the repository to push the scenario directory to,
+// derived from the challenge name.
+func (cy *ChallengeYAML) scenarioRepository() string {
+	return strings.Trim(nonRepositoryChars.ReplaceAllString(strings.ToLower(cy.Name), "-"), "-")
+}
+
+// model converts the challenge to the dynamic_iac one. The scenario is the OCI
+// reference to use, and challenges names are resolved to their identifier.
+func (cy *ChallengeYAML) model(dir, scenario string, challenges map[string]int) (*ChallengeDynamicIaCModel, error) {
+	resolve := func(ref string) (string, error) {
+		if _, err := strconv.Atoi(ref); err == nil {
+			return ref, nil
+		}
+		id, ok := challenges[ref]
+		if !ok {
+			return "", fmt.Errorf("challenge %s does not exist", ref)
+		}
+		return strconv.Itoa(id), nil
+	}
+
+	value := cy.Value
+	if cy.Extra.Initial != nil {
+		value = cy.Extra.Initial
+	}
+	state := cy.State
+	if state == "" {
+		state = "visible"
+	}
+	function := "linear"
+	if cy.Extra.Function != nil {
+		function = *cy.Extra.Function
+	}
+	flagMode := cy.Extra.FlagMode
+	if flagMode == "" {
+		flagMode = flagModeStatic
+	}
+	if !slices.Contains(flagModes, flagMode) {
+		return nil, fmt.Errorf("flag mode %s is not one of %s", flagMode, strings.Join(flagModes, ", "))
+	}
+	add := map[string]attr.Value{}
+	for k, v := range cy.Extra.Additional {
+		add[k] = types.StringValue(v)
+	}
+
+	chall := &ChallengeDynamicIaCModel{
+		Shared:        types.BoolValue(cy.Extra.Shared),
+		DestroyOnFlag: types.BoolValue(cy.Extra.DestroyOnFlag),
+		ManaCost:      types.Int64Value(int64(cy.Extra.ManaCost)),
+		Scenario:      types.StringValue(scenario),
+		Timeout:       utils.ToTFInt64(cy.Extra.Timeout),
+		Until:         types.StringPointerValue(cy.Extra.Until),
+		Additional:    types.MapValueMust(types.StringType, add),
+		Min:           types.Int64Value(int64(cy.Extra.Min)),
+		Max:           types.Int64Value(int64(cy.Extra.Max)),
+		FlagMode:      types.StringValue(flagMode),
+	}
+	chall.Name = types.StringValue(cy.Name)
+	chall.Category = types.StringValue(cy.Category)
+	chall.Description = types.StringValue(cy.Description)
+	chall.Attribution = types.StringPointerValue(cy.Attribution)
+	chall.ConnectionInfo = types.StringPointerValue(cy.ConnectionInfo)
+	chall.MaxAttempts = utils.ToTFInt64(cy.Attempts)
+	chall.Function = types.StringValue(function)
+	chall.Value = utils.ToTFInt64(value)
+	chall.Decay = utils.ToTFInt64(cy.Extra.Decay)
+	chall.Minimum = utils.ToTFInt64(cy.Extra.Minimum)
+	chall.Logic = types.StringValue("any")
+	chall.State = types.StringValue(state)
+	chall.Next = types.Int64Null()
+	if cy.Next != "" {
+		id, err := resolve(cy.Next)
+		if err != nil {
+			return nil, fmt.Errorf("next: %w", err)
+		}
+		next, _ := strconv.Atoi(id)
+		chall.Next = types.Int64Value(int64(next))
+	}
+	if cy.Requirements != nil {
+		behavior := "hidden"
+		if cy.Requirements.Anonymize {
+			behavior = "anonymized"
+		}
+		reqs := &tfctfd.RequirementsSubresourceModel{
+			Behavior: types.StringValue(behavior),
+		}
+		for _, preq := range cy.Requirements.Prerequisites {
+			id, err := resolve(preq)
+			if err != nil {
+				return nil, fmt.Errorf("requirements: %w", err)
+			}
+			reqs.Prerequisites = append(reqs.Prerequisites, types.StringValue(id))
+		}
+		chall.Requirements = reqs
+	}
+	for _, tag := range cy.Tags {
+		chall.Tags = append(chall.Tags, types.StringValue(tag))
+	}
+	for _, topic := range cy.Topics {
+		chall.Topics = append(chall.Topics, types.StringValue(topic))
+	}
+
+	// Subresources are all created, as they are not tracked by identifier
+	for _, flag := range cy.Flags {
+		typ, data := flag.Type, flag.Data
+		if typ == "" {
+			typ = "static"
+		}
+		if data == "" {
+			data = "case_sensitive"
+		}
+		chall.Flags = append(chall.Flags, ChallengeFlagModel{
+			ID:      types.StringUnknown(),
+			Content: types.StringValue(flag.Content),
+			Data:    types.StringValue(data),
+			Type:    types.StringValue(typ),
+		})
+	}
+	for _, hint := range cy.Hints {
+		chall.Hints = append(chall.Hints, ChallengeHintModel{
+			ID:      types.StringUnknown(),
+			Content: types.StringValue(hint.Content),
+			Cost:    types.Int64Value(int64(hint.Cost)),
+		})
+	}
+	for _, file := range cy.Files {
+		chall.Files = append(chall.Files, ChallengeFileModel{
+			ID:            types.StringUnknown(),
+			Name:          types.StringValue(filepath.Base(file)),
+			Path:          types.StringValue(filepath.Join(dir, file)),
+			ContentBase64: types.StringNull(),
+			SHA256:        types.StringUnknown(),
+			Location:      types.StringUnknown(),
+		})
+	}
+	return chall, nil
+}
+
+// bundleSHA256 computes the SHA256 sum of all the files of a bundle directory and
+// of its scenario directory if any, along with their relative path, such that any
+// change in them is detected. The scenario directory could be out of the bundle one.
+func bundleSHA256(dir, scenarioDir string) (string, error) {
+	h := sha256.New()
+	walk := func(root, prefix string) error {
+		return filepath.WalkDir(root, func(pth string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(root, pth)
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(pth)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			_, _ = io.WriteString(h, prefix+filepath.ToSlash(rel)+"\x00")
+			_, err = io.Copy(h, f)
+			return err
+		})
+	}
+	if err := walk(dir, ""); err != nil {
+		return "", err
+	}
+	if scenarioDir != "" {
+		if err := walk(scenarioDir, "scenario:"); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ctfer-io/chall-manager/pkg/scenario"
+	ctfd "github.com/ctfer-io/go-ctfd/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ resource.Resource               = (*challengeBundleResource)(nil)
+	_ resource.ResourceWithConfigure  = (*challengeBundleResource)(nil)
+	_ resource.ResourceWithModifyPlan = (*challengeBundleResource)(nil)
+)
+
+func NewChallengeBundleResource() resource.Resource {
+	return &challengeBundleResource{}
+}
+
+type challengeBundleResource struct {
+	fm *Framework
+}
+
+type ChallengeBundleResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Directory        types.String `tfsdk:"directory"`
+	ScenarioRef      types.String `tfsdk:"scenario_ref"`
+	RegistryInsecure types.Bool   `tfsdk:"registry_insecure"`
+	RegistryUsername types.String `tfsdk:"registry_username"`
+	RegistryPassword types.String `tfsdk:"registry_password"`
+	SHA256           types.String `tfsdk:"sha256"`
+	Name             types.String `tfsdk:"name"`
+	Scenario         types.String `tfsdk:"scenario"`
+}
+
+func (r *challengeBundleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_bundle"
+}
+
+func (r *challengeBundleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A dynamic_iac challenge defined by a [ctfcli](https://github.com/CTFd/ctfcli) bundle, i.e. a directory with a `challenge.yml` file. It eases the adoption of Terraform for challenges already shipped this way.\n\n" +
+			"The CTFd-Chall-Manager plugin attributes are set under `extra` (`shared`, `destroy_on_flag`, `mana_cost`, `scenario`, `timeout`, `until`, `additional`, `min`, `max` and `flag_mode`), along with the dynamic value ones (`initial`, `decay`, `minimum` and `function`). " +
+			"The `extra.scenario` is either an OCI reference, or a directory of the bundle that is pushed as a scenario.\n\n" +
+			"Requirements and next challenge could be given by name or identifier. Changes are tracked through the content of the directory, and the flags, hints and files are then all recreated.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the challenge.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"directory": schema.StringAttribute{
+				MarkdownDescription: "The directory of the bundle, containing the `challenge.yml` file.",
+				Required:            true,
+			},
+			"scenario_ref": schema.StringAttribute{
+				MarkdownDescription: "The OCI reference to push the scenario directory to, if `extra.scenario` is one. Defaults to the challenge name tagged with the bundle content digest, under the provider `scenario_registry`, one of which must be set.",
+				Optional:            true,
+			},
+			"registry_insecure": schema.BoolAttribute{
+				MarkdownDescription: "Whether to push the scenario directory to the OCI registry over HTTP.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+			"registry_username": schema.StringAttribute{
+				MarkdownDescription: "The username to authenticate to the OCI registry to push the scenario directory to.",
+				Optional:            true,
+			},
+			"registry_password": schema.StringAttribute{
+				MarkdownDescription: "The password to authenticate to the OCI registry to push the scenario directory to.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 sum of the bundle directory content, along with its scenario directory if any.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the challenge, as defined in the `challenge.yml` file.",
+				Computed:            true,
+			},
+			"scenario": schema.StringAttribute{
+				MarkdownDescription: "The OCI reference to the scenario of the challenge.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *challengeBundleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *challengeBundleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ChallengeBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Directory.IsUnknown() || data.ScenarioRef.IsUnknown() {
+		return
+	}
+
+	// Read the bundle at plan time, such that it is validated and its changes planned
+	cy, sum := r.read(data.Directory.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A scenario directory is pushed to the scenario_ref, else under the provider
+	// scenario_registry, so one of them is needed
+	if _, ok := cy.scenarioDir(data.Directory.ValueString()); ok && data.ScenarioRef.IsNull() && (r.fm == nil || r.fm.ScenarioRegistry == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scenario_ref"),
+			"Missing Scenario Reference",
+			fmt.Sprintf("The extra.scenario of bundle %s is a directory, so either scenario_ref or the provider scenario_registry must be set to push it.", data.Directory.ValueString()),
+		)
+		return
+	}
+
+	data.SHA256 = types.StringValue(sum)
+	data.Name = types.StringValue(cy.Name)
+	data.Scenario = types.StringValue(r.scenario(cy, &data))
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *challengeBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ChallengeBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chall := r.challenge(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	chall.create(ctx, r.fm, &resp.Diagnostics)
+
+	// Save computed attributes in state
+	data.ID = chall.ID
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ChallengeBundleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the name and scenario are read back, so changes in CTFd are planned
+	res, _, err := r.fm.Client.GetChallenge(ctx, data.ID.ValueString(), WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read challenge %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+	data.Name = types.StringValue(res.Name)
+	data.Scenario = types.StringValue(res.Scenario)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data, dataState ChallengeBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = dataState.ID
+
+	chall := r.challenge(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	chall.ID = data.ID
+
+	// Subresources are not tracked by identifier, so all the existing ones are replaced
+	prior := &ChallengeDynamicIaCModel{}
+	prior.ID = data.ID
+	prior.readFlags(ctx, r.fm.Client, &resp.Diagnostics, WithTracerProvider(r.fm.Tp))
+	prior.readHints(ctx, r.fm.Client, &resp.Diagnostics, WithTracerProvider(r.fm.Tp))
+	prior.readFiles(ctx, r.fm.Client, &resp.Diagnostics, WithTracerProvider(r.fm.Tp))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	chall.update(ctx, r.fm, prior, nil, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeBundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ChallengeBundleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.fm.Client.DeleteChallenge(ctx, data.ID.ValueString(), WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
+		return
+	}
+
+	// ... don't need to delete nested objects, this is handled by CTFd
+}
+
+// read reads the bundle challenge.yml and computes its content digest.
+func (r *challengeBundleResource) read(dir string, diags *diag.Diagnostics) (*ChallengeYAML, string) {
+	cy, err := readChallengeYAML(dir)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("directory"),
+			"Invalid Bundle",
+			fmt.Sprintf("Unable to read bundle %s, got error: %s", dir, err),
+		)
+		return nil, ""
+	}
+	sdir, ok := cy.scenarioDir(dir)
+	if !ok {
+		sdir = ""
+	}
+	sum, err := bundleSHA256(dir, sdir)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("directory"),
+			"Invalid Bundle",
+			fmt.Sprintf("Unable to compute digest of bundle %s, got error: %s", dir, err),
+		)
+		return nil, ""
+	}
+	return cy, sum
+}
+
+// scenario returns the OCI reference of the bundle scenario.
+func (r *challengeBundleResource) scenario(cy *ChallengeYAML, data *ChallengeBundleResourceModel) string {
+	if _, ok := cy.scenarioDir(data.Directory.ValueString()); !ok {
+		return r.fm.scenarioRef(cy.Extra.Scenario)
+	}
+	if !data.ScenarioRef.IsNull() {
+		return r.fm.scenarioRef(data.ScenarioRef.ValueString())
+	}
+	return r.fm.scenarioRef(cy.scenarioRepository() + ":" + data.SHA256.ValueString()[:12])
+}
+
+// challenge reads the bundle, pushes its scenario directory if it is one,
+// and returns the corresponding dynamic_iac challenge.
+func (r *challengeBundleResource) challenge(ctx context.Context, data *ChallengeBundleResourceModel, diags *diag.Diagnostics) *ChallengeDynamicIaCModel {
+	dir := data.Directory.ValueString()
+	cy, sum := r.read(dir, diags)
+	if diags.HasError() {
+		return nil
+	}
+	// The directory could have been unknown at plan time
+	data.SHA256 = types.StringValue(sum)
+	data.Name = types.StringValue(cy.Name)
+	data.Scenario = types.StringValue(r.scenario(cy, data))
+
+	if sdir, ok := cy.scenarioDir(dir); ok {
+		if err := scenario.EncodeOCI(ctx, data.Scenario.ValueString(), sdir, data.RegistryInsecure.ValueBool(), data.RegistryUsername.ValueString(), data.RegistryPassword.ValueString()); err != nil {
+			diags.AddError(
+				"Scenario Error",
+				fmt.Sprintf("Unable to push scenario %s to %s, got error: %s", sdir, data.Scenario.ValueString(), err),
+			)
+			return nil
+		}
+	}
+
+	// Requirements and next challenge could be given by name
+	challs, _, err := r.fm.Client.GetChallenges(ctx, &ctfd.GetChallengesParams{}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get challenges, got error: %s", err),
+		)
+		return nil
+	}
+	ids := make(map[string]int, len(challs))
+	for _, c := range challs {
+		ids[c.Name] = c.ID
+	}
+
+	chall, err := cy.model(dir, data.Scenario.ValueString(), ids)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("directory"),
+			"Invalid Bundle",
+			fmt.Sprintf("Unable to convert bundle %s, got error: %s", dir, err),
+		)
+		return nil
+	}
+	return chall
+}
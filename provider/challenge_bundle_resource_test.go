@@ -0,0 +1,129 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAcc_ChallengeBundle_Lifecycle(t *testing.T) {
+	cfg := `
+resource "ctfdcm_challenge_dynamiciac" "prerequisite" {
+	name        = "Bundle prerequisite"
+	category    = "misc"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+resource "ctfdcm_challenge_bundle" "bundle" {
+	directory         = "${path.cwd}/testdata/bundle"
+	scenario_ref      = var.scenario_ref
+	registry_insecure = true
+
+	# The requirements are resolved by name
+	depends_on = [ctfdcm_challenge_dynamiciac.prerequisite]
+}
+
+data "ctfdcm_challenges_dynamiciac" "all" {
+	depends_on = [ctfdcm_challenge_bundle.bundle]
+}
+
+locals {
+	bundled = one([for c in data.ctfdcm_challenges_dynamiciac.all.challenges : c if c.id == ctfdcm_challenge_bundle.bundle.id])
+}
+
+output "flags" {
+	value     = [for f in local.bundled.flags : f.type]
+	sensitive = true
+}
+
+output "hints" {
+	value = [for h in local.bundled.hints : h.cost]
+}
+
+output "files" {
+	value = [for f in local.bundled.files : f.name]
+}
+
+output "requirements" {
+	value = local.bundled.requirements.prerequisites == toset([ctfdcm_challenge_dynamiciac.prerequisite.id])
+}
+
+variable "scenario" {
+  type = string
+}
+
+variable "scenario_ref" {
+  type = string
+}
+`
+	vars := config.Variables{
+		"scenario":     config.StringVariable(ref),
+		"scenario_ref": config.StringVariable(REGISTRY + "/bundle:v0.1.0"),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          cfg,
+				ConfigVariables: vars,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_bundle.bundle", tfjsonpath.New("name"), knownvalue.StringExact("Bundled challenge")),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_bundle.bundle", tfjsonpath.New("scenario"), knownvalue.StringExact(REGISTRY+"/bundle:v0.1.0")),
+					statecheck.ExpectKnownOutputValue("flags", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("static"),
+						knownvalue.StringExact("regex"),
+					})),
+					statecheck.ExpectKnownOutputValue("hints", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Int64Exact(0),
+						knownvalue.Int64Exact(10),
+					})),
+					statecheck.ExpectKnownOutputValue("files", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("note.txt"),
+					})),
+					statecheck.ExpectKnownOutputValue("requirements", knownvalue.Bool(true)),
+				},
+			},
+			// The bundle must not produce changes while not changed
+			{
+				Config:          cfg,
+				ConfigVariables: vars,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeBundle_MissingScenarioRef(t *testing.T) {
+	// The scenario directory could not be pushed anywhere
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "ctfdcm_challenge_bundle" "bundle" {
+	directory = "${path.cwd}/testdata/bundle"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Scenario Reference`),
+			},
+		},
+	})
+}
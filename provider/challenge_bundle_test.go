@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_BundleSHA256(t *testing.T) {
+	dir, sdir := t.TempDir(), t.TempDir()
+	write := func(pth, content string) {
+		t.Helper()
+		if err := os.WriteFile(pth, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(dir, challengeYAMLFile), "name: Some challenge\n")
+	write(filepath.Join(sdir, "main.go"), "package main\n")
+
+	before, err := bundleSHA256(dir, sdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A change in the scenario directory, out of the bundle one, must be detected
+	write(filepath.Join(sdir, "main.go"), "package main\n\nfunc main() {}\n")
+	after, err := bundleSHA256(dir, sdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before == after {
+		t.Errorf("expected the digest to change with the scenario, got %s", after)
+	}
+
+	// Without scenario directory, only the bundle is considered
+	only, err := bundleSHA256(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if only == after {
+		t.Errorf("expected the digest to differ without the scenario, got %s", only)
+	}
+}
+
+func Test_ReadChallengeYAML_Type(t *testing.T) {
+	var tests = map[string]struct {
+		Type      string
+		ExpectErr bool
+	}{
+		"none": {
+			Type: "",
+		},
+		"dynamic": {
+			Type: "dynamic",
+		},
+		"dynamic_iac": {
+			Type: "dynamic_iac",
+		},
+		"standard": {
+			Type:      "standard",
+			ExpectErr: true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			dir := t.TempDir()
+			content := "name: Some challenge\ncategory: misc\nvalue: 500\nextra:\n  decay: 20\n  minimum: 50\n  scenario: localhost:5000/some/scenario:v0.1.0\n"
+			if tt.Type != "" {
+				content += "type: " + tt.Type + "\n"
+			}
+			if err := os.WriteFile(filepath.Join(dir, challengeYAMLFile), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := readChallengeYAML(dir)
+			if (err != nil) != tt.ExpectErr {
+				t.Errorf("expected error %t, got %v", tt.ExpectErr, err)
+			}
+		})
+	}
+}
@@ -27,8 +27,8 @@ type challengeDynamicIaCDataSource struct {
 }
 
 type challengesDynamicDataSourceModel struct {
-	ID         types.String                       `tfsdk:"id"`
-	Challenges []ChallengeDynamicIaCResourceModel `tfsdk:"challenges"`
+	ID         types.String               `tfsdk:"id"`
+	Challenges []ChallengeDynamicIaCModel `tfsdk:"challenges"`
 }
 
 func (data *challengeDynamicIaCDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -134,6 +134,10 @@ func (data *challengeDynamicIaCDataSource) Schema(ctx context.Context, req datas
 							MarkdownDescription: "The cost (in mana) of the challenge once an instance is deployed.",
 							Computed:            true,
 						},
+						"flag_mode": schema.StringAttribute{
+							MarkdownDescription: "How submissions are validated, either `static`, `instance` or `both`.",
+							Computed:            true,
+						},
 						"scenario": schema.StringAttribute{
 							MarkdownDescription: "The OCI reference to the scenario.",
 							Computed:            true,
@@ -159,6 +163,98 @@ func (data *challengeDynamicIaCDataSource) Schema(ctx context.Context, req datas
 							MarkdownDescription: "The number of instances after which not to pool anymore.",
 							Computed:            true,
 						},
+						"tags_all": schema.SetAttribute{
+							MarkdownDescription: "All the tags of the challenge, including the provider `default_tags`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"topics_all": schema.SetAttribute{
+							MarkdownDescription: "All the topics of the challenge, including the provider `default_topics`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"flags": schema.ListNestedAttribute{
+							MarkdownDescription: "The flags of the challenge.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "Identifier of the flag.",
+										Computed:            true,
+									},
+									"content": schema.StringAttribute{
+										MarkdownDescription: "The actual flag, or the regular expression to match it.",
+										Computed:            true,
+										Sensitive:           true,
+									},
+									"data": schema.StringAttribute{
+										MarkdownDescription: "Either `case_sensitive` or `case_insensitive`.",
+										Computed:            true,
+									},
+									"type": schema.StringAttribute{
+										MarkdownDescription: "Either `static` or `regex`.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"hints": schema.ListNestedAttribute{
+							MarkdownDescription: "The hints of the challenge.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "Identifier of the hint.",
+										Computed:            true,
+									},
+									"content": schema.StringAttribute{
+										MarkdownDescription: "Content of the hint as displayed to the end-user.",
+										Computed:            true,
+									},
+									"cost": schema.Int64Attribute{
+										MarkdownDescription: "Cost (in points) of the hint to unlock it.",
+										Computed:            true,
+									},
+									"requirements": schema.ListAttribute{
+										MarkdownDescription: "Indexes of the previous hints of the challenge to unlock before this one.",
+										ElementType:         types.Int64Type,
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"files": schema.ListNestedAttribute{
+							MarkdownDescription: "The files of the challenge.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "Identifier of the file.",
+										Computed:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Name of the file as displayed to the end-user.",
+										Computed:            true,
+									},
+									"path": schema.StringAttribute{
+										MarkdownDescription: "Not read from CTFd, thus always null.",
+										Computed:            true,
+									},
+									"content_base64": schema.StringAttribute{
+										MarkdownDescription: "Not read from CTFd, thus always null.",
+										Computed:            true,
+									},
+									"sha256": schema.StringAttribute{
+										MarkdownDescription: "Not read from CTFd, thus always null.",
+										Computed:            true,
+									},
+									"location": schema.StringAttribute{
+										MarkdownDescription: "Location of the file in CTFd.",
+										Computed:            true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -202,11 +298,11 @@ func (data *challengeDynamicIaCDataSource) Read(ctx context.Context, req datasou
 	}
 
 	// Then get their actual data
-	state.Challenges = make([]ChallengeDynamicIaCResourceModel, 0, len(challs))
+	state.Challenges = make([]ChallengeDynamicIaCModel, 0, len(challs))
 	for _, c := range challs {
-		chall := ChallengeDynamicIaCResourceModel{}
+		chall := ChallengeDynamicIaCModel{}
 		chall.ID = types.StringValue(strconv.Itoa(c.ID))
-		chall.Read(ctx, data.fm.Client, resp.Diagnostics, WithTracerProvider(data.fm.Tp))
+		chall.Read(ctx, data.fm.Client, &resp.Diagnostics, WithTracerProvider(data.fm.Tp))
 		if resp.Diagnostics.HasError() {
 			return
 		}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+var (
+	_ list.ListResource              = (*challengeDynamicIaCListResource)(nil)
+	_ list.ListResourceWithConfigure = (*challengeDynamicIaCListResource)(nil)
+)
+
+func NewChallengeDynamicIaCListResource() list.ListResource {
+	return &challengeDynamicIaCListResource{}
+}
+
+type challengeDynamicIaCListResource struct {
+	fm *Framework
+}
+
+type challengeDynamicIaCListResourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Category types.String `tfsdk:"category"`
+	State    types.String `tfsdk:"state"`
+}
+
+func (r *challengeDynamicIaCListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_dynamiciac"
+}
+
+func (r *challengeDynamicIaCListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the dynamic_iac challenges of CTFd, for instance to adopt the ones created manually.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only list the challenges with this name.",
+				Optional:            true,
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "Only list the challenges of this category.",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Only list the challenges in this state, either hidden or visible.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *challengeDynamicIaCListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *challengeDynamicIaCListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+
+	var filter challengeDynamicIaCListResourceModel
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		span.End()
+		return
+	}
+
+	challs, _, err := r.fm.Client.GetChallenges(ctx, &api.GetChallengesParams{
+		Type:     utils.Ptr("dynamic_iac"),
+		Name:     filter.Name.ValueStringPointer(),
+		Category: filter.Category.ValueStringPointer(),
+		State:    filter.State.ValueStringPointer(),
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list challenges, got error: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		span.End()
+		return
+	}
+
+	// The results are streamed once returned, so the span ends along
+	stream.Results = func(push func(list.ListResult) bool) {
+		defer span.End()
+
+		for _, c := range challs {
+			result := req.NewListResult(ctx)
+			result.DisplayName = c.Name
+
+			id := types.StringValue(strconv.Itoa(c.ID))
+			result.Diagnostics.Append(result.Identity.Set(ctx, &challengeDynamicIaCIdentityModel{
+				ID: id,
+			})...)
+
+			if req.IncludeResource {
+				chall := ChallengeDynamicIaCResourceModel{
+					PrewarmOnUpdate: types.BoolValue(false),
+				}
+				chall.ID = id
+				chall.Read(ctx, r.fm.Client, &result.Diagnostics, WithTracerProvider(r.fm.Tp))
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &chall)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
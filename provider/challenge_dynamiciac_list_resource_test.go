@@ -0,0 +1,58 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_ChallengeDynamicIaCList_Query(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "listed" {
+	name        = "Listed challenge"
+	category    = "list-query"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+			{
+				Query: true,
+				Config: providerConfig + `
+list "ctfdcm_challenge_dynamiciac" "listed" {
+	provider = ctfdcm
+
+	config {
+		category = "list-query"
+	}
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("ctfdcm_challenge_dynamiciac.listed", 1),
+				},
+			},
+		},
+	})
+}
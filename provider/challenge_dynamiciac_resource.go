@@ -4,19 +4,24 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	ctfd "github.com/ctfer-io/go-ctfd/api"
 	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
@@ -25,11 +30,29 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*challengeDynamicIaCResource)(nil)
-	_ resource.ResourceWithConfigure   = (*challengeDynamicIaCResource)(nil)
-	_ resource.ResourceWithImportState = (*challengeDynamicIaCResource)(nil)
+	_ resource.Resource                   = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithConfigure      = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithImportState    = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithMoveState      = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithUpgradeState   = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithIdentity       = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*challengeDynamicIaCResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*challengeDynamicIaCResource)(nil)
 )
 
+const (
+	// privateKeyTypeMigration is the private state key set when a challenge is moved
+	// from another challenge type, such that the next Update converts it to dynamic_iac.
+	privateKeyTypeMigration = "type_migration"
+
+	// flagModeStatic is the flag mode validating submissions against the challenge flags
+	// only, as CTFd does by default.
+	flagModeStatic = "static"
+)
+
+// flagModes are the ways submissions could be validated.
+var flagModes = []string{flagModeStatic, "instance", "both"}
+
 func NewChallengeDynamicIaCResource() resource.Resource {
 	return &challengeDynamicIaCResource{}
 }
@@ -39,6 +62,15 @@ type challengeDynamicIaCResource struct {
 }
 
 type ChallengeDynamicIaCResourceModel struct {
+	ChallengeDynamicIaCModel
+
+	PrewarmOnUpdate types.Bool  `tfsdk:"prewarm_on_update"`
+	PrewarmTimeout  types.Int64 `tfsdk:"prewarm_timeout"`
+}
+
+// ChallengeDynamicIaCModel contains the attributes of a dynamic_iac challenge that
+// are stored in CTFd, shared by the resource and the data source.
+type ChallengeDynamicIaCModel struct {
 	tfctfd.ChallengeDynamicResourceModel
 
 	Shared        types.Bool   `tfsdk:"shared"`
@@ -50,6 +82,18 @@ type ChallengeDynamicIaCResourceModel struct {
 	Additional    types.Map    `tfsdk:"additional"`
 	Min           types.Int64  `tfsdk:"min"`
 	Max           types.Int64  `tfsdk:"max"`
+	FlagMode      types.String `tfsdk:"flag_mode"`
+
+	TagsAll   types.Set `tfsdk:"tags_all"`
+	TopicsAll types.Set `tfsdk:"topics_all"`
+
+	Flags []ChallengeFlagModel `tfsdk:"flags"`
+	Hints []ChallengeHintModel `tfsdk:"hints"`
+	Files []ChallengeFileModel `tfsdk:"files"`
+}
+
+type challengeDynamicIaCIdentityModel struct {
+	ID types.String `tfsdk:"id"`
 }
 
 func (r *challengeDynamicIaCResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,11 +102,23 @@ func (r *challengeDynamicIaCResource) Metadata(ctx context.Context, req resource
 
 func (r *challengeDynamicIaCResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nThis implementation has support of On Demand infrastructures through [Chall-Manager](https://github.com/ctfer-io/chall-manager).",
+		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nThis implementation has support of On Demand infrastructures through [Chall-Manager](https://github.com/ctfer-io/chall-manager).\n\nA `ctfd_challenge_dynamic` could be moved to it through a `moved` block, keeping its solves. The configuration must then set the `scenario`, which the moved state lacks and is applied through an in-place update.",
+		Version:             1,
 		Attributes:          ChallengeDynamicIaCResourceAttributes,
 	}
 }
 
+func (r *challengeDynamicIaCResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Identifier of the challenge.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *challengeDynamicIaCResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -81,6 +137,98 @@ func (r *challengeDynamicIaCResource) Configure(ctx context.Context, req resourc
 	r.fm = fm
 }
 
+func (r *challengeDynamicIaCResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var preqs types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("requirements").AtName("prerequisites"), &preqs)...)
+	if !resp.Diagnostics.HasError() && !preqs.IsNull() && !preqs.IsUnknown() {
+		validatePrerequisites(preqs, &resp.Diagnostics)
+	}
+
+	var hints types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hints"), &hints)...)
+	if resp.Diagnostics.HasError() || hints.IsNull() || hints.IsUnknown() {
+		return
+	}
+
+	validateHintRequirements(hints, &resp.Diagnostics)
+}
+
+func (r *challengeDynamicIaCResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	matchSubresources(ctx, req, resp)
+	if r.fm == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The provider defaults are applied here rather than in the schema, as the latter
+	// is built from a resource that is never configured
+	r.fm.applyDefaults(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	// Only check the requirements and mana budget if asked to, as they need to fetch
+	// the existing challenges and settings
+	if r.fm.RequirementsCheck {
+		r.checkRequirements(ctx, req, resp)
+	}
+	if r.fm.ManaBudgetCheck {
+		r.checkManaBudget(ctx, req, resp)
+	}
+}
+
+// checkManaBudget warns if the planned challenge costs mana, and the mana cost of all
+// the challenges exceeds the mana total. The other challenges are considered as they
+// exist, such that the outcome does not depend on the order of the plans.
+func (r *challengeDynamicIaCResource) checkManaBudget(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var id, name types.String
+	var cost types.Int64
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("mana_cost"), &cost)...)
+	if resp.Diagnostics.HasError() || cost.IsUnknown() || cost.ValueInt64() == 0 {
+		return
+	}
+
+	if err := r.fm.manaBudget.load(ctx, r.fm); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Mana Budget Check Skipped",
+			fmt.Sprintf("Unable to check the mana budget, got error: %s", err),
+		)
+		return
+	}
+
+	// Challenges not created yet have no identifier, which no existing one matches
+	cid := 0
+	if !id.IsUnknown() {
+		cid, _ = strconv.Atoi(id.ValueString())
+	}
+	sum, challs := r.fm.manaBudget.plan(cid, challengeManaCost{
+		Name: name.ValueString(),
+		Cost: int(cost.ValueInt64()),
+	})
+	if len(challs) == 0 {
+		return
+	}
+
+	lines := make([]string, 0, len(challs))
+	for _, c := range challs {
+		lines = append(lines, fmt.Sprintf("  - %s: %d", c.Name, c.Cost))
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("mana_cost"),
+		"Mana Budget Exceeded",
+		fmt.Sprintf("The challenges cost %d mana in total while the mana total is %d, so no source could run all instances at once. The challenges costing mana are:\n%s", sum, r.fm.manaBudget.total, strings.Join(lines, "\n")),
+	)
+}
+
 func (r *challengeDynamicIaCResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
 	defer span.End()
@@ -91,74 +239,322 @@ func (r *challengeDynamicIaCResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
-	// Create Challenge
-	reqs := (*ctfd.Requirements)(nil)
-	if data.Requirements != nil {
-		preqs := make([]int, 0, len(data.Requirements.Prerequisites))
-		for _, preq := range data.Requirements.Prerequisites {
-			id, _ := strconv.Atoi(preq.ValueString())
-			preqs = append(preqs, id)
+	data.create(ctx, r.fm, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &challengeDynamicIaCIdentityModel{
+		ID: data.ID,
+	})...)
+}
+
+func (r *challengeDynamicIaCResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ChallengeDynamicIaCResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scenario, tags, topics := data.Scenario, data.Tags, data.Topics
+	flags, hints, files := data.Flags, data.Hints, data.Files
+	// The name is required, so it is only null when the challenge is imported
+	imported := data.Name.IsNull()
+	data.Read(ctx, r.fm.Client, &resp.Diagnostics, WithTracerProvider(r.fm.Tp))
+	// Flags, hints and files could be managed aside (e.g. with ctfd_flag), so they are
+	// only tracked if they already were, or on import
+	if !imported {
+		if flags == nil {
+			data.Flags = nil
 		}
-		reqs = &ctfd.Requirements{
-			Anonymize:     tfctfd.FromBehavior(data.Requirements.Behavior),
-			Prerequisites: preqs,
+		if hints == nil {
+			data.Hints = nil
 		}
+		if files == nil {
+			data.Files = nil
+		}
+	}
+	// Keep the provider default tags and topics in tags_all and topics_all only
+	data.Tags = withoutDefaults(data.Tags, tags, r.fm.defaultTags())
+	data.Topics = withoutDefaults(data.Topics, topics, r.fm.defaultTopics())
+	if r.fm.scenarioRef(scenario.ValueString()) == data.Scenario.ValueString() {
+		// Keep the short reference, as completed with the provider scenario registry
+		data.Scenario = scenario
+	}
+	if data.PrewarmOnUpdate.IsNull() {
+		// Not read from CTFd, e.g. on import
+		data.PrewarmOnUpdate = types.BoolValue(false)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &challengeDynamicIaCIdentityModel{
+		ID: data.ID,
+	})...)
+}
+
+func (r *challengeDynamicIaCResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ChallengeDynamicIaCResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var dataState ChallengeDynamicIaCResourceModel
+	req.State.Get(ctx, &dataState)
+
+	// If the challenge has been moved from another type, convert it
+	typ := (*string)(nil)
+	migration, diags := req.Private.GetKey(ctx, privateKeyTypeMigration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(migration) != 0 {
+		typ = utils.Ptr("dynamic_iac")
+	}
+
+	data.update(ctx, r.fm, &dataState.ChallengeDynamicIaCModel, typ, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if typ != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyTypeMigration, nil)...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Pre-warm the pool, if configured. The challenge is already updated, so a failure
+	// does not make the update fail
+	if data.PrewarmOnUpdate.ValueBool() && data.Min.ValueInt64() > 0 {
+		timeout := defaultPrewarmTimeout
+		if !data.PrewarmTimeout.IsNull() {
+			timeout = time.Duration(data.PrewarmTimeout.ValueInt64()) * time.Second
+		}
+		if err := prewarmPool(ctx, r.fm, data.ID.ValueString(), int(data.Min.ValueInt64()), timeout, func(msg string) {
+			logInfo(ctx, msg)
+		}); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Pre-warm Error",
+				fmt.Sprintf("Unable to pre-warm pool of challenge %s, got error: %s", data.ID.ValueString(), err),
+			)
+		}
+	}
+}
+
+func (r *challengeDynamicIaCResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ChallengeDynamicIaCResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.fm.Client.DeleteChallenge(ctx, data.ID.ValueString(), WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
+		return
+	}
+
+	// ... don't need to delete nested objects, this is handled by CTFd
+}
+
+func (r *challengeDynamicIaCResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
+
+func (r *challengeDynamicIaCResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: &schema.Schema{
+				Attributes: tfctfd.ChallengeDynamicResourceAttributes,
+			},
+			StateMover: r.moveFromChallengeDynamic,
+		},
+	}
+}
+
+// moveFromChallengeDynamic moves a ctfd_challenge_dynamic from the Terraform Provider
+// for CTFd to a ctfdcm_challenge_dynamiciac.
+// The shared attributes are carried over, and the challenge type is patched to
+// dynamic_iac on next Update rather than recreated, such that solves are kept.
+func (r *challengeDynamicIaCResource) moveFromChallengeDynamic(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "ctfd_challenge_dynamic" || !strings.HasSuffix(req.SourceProviderAddress, "ctfer-io/ctfd") {
+		// Let other state movers, if any, handle it
+		return
+	}
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			fmt.Sprintf("The source state of %s could not be decoded, the schema version %d may not be supported.", req.SourceTypeName, req.SourceSchemaVersion),
+		)
+		return
+	}
+
+	var source tfctfd.ChallengeDynamicResourceModel
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := ChallengeDynamicIaCResourceModel{
+		ChallengeDynamicIaCModel: ChallengeDynamicIaCModel{
+			ChallengeDynamicResourceModel: source,
+			// CTFd-Chall-Manager plugin attributes are defaulted, the scenario
+			// will then be set through an in-place update.
+			Shared:        types.BoolValue(false),
+			DestroyOnFlag: types.BoolValue(false),
+			ManaCost:      types.Int64Value(0),
+			Scenario:      types.StringNull(),
+			Timeout:       types.Int64Null(),
+			Until:         types.StringNull(),
+			Additional:    basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+			Min:           types.Int64Value(0),
+			Max:           types.Int64Value(0),
+			FlagMode:      types.StringValue(flagModeStatic),
+			TagsAll:       stringSetValue(source.Tags),
+			TopicsAll:     stringSetValue(source.Topics),
+		},
+		// Provider-only attributes
+		PrewarmOnUpdate: types.BoolValue(false),
+	}
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, &challengeDynamicIaCIdentityModel{
+		ID: data.ID,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.TargetPrivate.SetKey(ctx, privateKeyTypeMigration, []byte(`{"from":"dynamic"}`))...)
+}
+
+func (r *challengeDynamicIaCResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: challengeDynamicIaCResourceAttributesV0,
+			},
+			StateUpgrader: upgradeChallengeDynamicIaCStateV0,
+		},
+	}
+}
+
+// upgradeChallengeDynamicIaCStateV0 upgrades a v0 state, where `until` could be
+// stored as an empty string and `additional` as null, to v1.
+func upgradeChallengeDynamicIaCStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior challengeDynamicIaCResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	until := prior.Until
+	if until.ValueString() == "" {
+		until = types.StringNull()
+	}
+	additional := prior.Additional
+	if additional.IsNull() {
+		additional = basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{})
+	}
+
+	data := ChallengeDynamicIaCResourceModel{
+		ChallengeDynamicIaCModel: ChallengeDynamicIaCModel{
+			ChallengeDynamicResourceModel: prior.ChallengeDynamicResourceModel,
+			Shared:                        prior.Shared,
+			DestroyOnFlag:                 prior.DestroyOnFlag,
+			ManaCost:                      prior.ManaCost,
+			Scenario:                      prior.Scenario,
+			Timeout:                       prior.Timeout,
+			Until:                         until,
+			Additional:                    additional,
+			Min:                           prior.Min,
+			Max:                           prior.Max,
+			FlagMode:                      types.StringValue(flagModeStatic),
+			TagsAll:                       stringSetValue(prior.Tags),
+			TopicsAll:                     stringSetValue(prior.Topics),
+		},
+		PrewarmOnUpdate: types.BoolValue(false),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// create creates the challenge in CTFd, along with its subresources and the
+// provider default tags and topics.
+func (chall *ChallengeDynamicIaCModel) create(ctx context.Context, fm *Framework, diags *diag.Diagnostics) {
+	// Create Challenge
+	reqs := requirementsParams(chall.Requirements, diags)
+	if diags.HasError() {
+		return
 	}
 	add := map[string]string{}
-	for k, tv := range data.Additional.Elements() {
+	for k, tv := range chall.Additional.Elements() {
 		add[k] = tv.(types.String).ValueString()
 	}
-	res, _, err := r.fm.Client.PostChallenges(ctx, &ctfdcm.PostChallengesParams{
+	res, _, err := fm.Client.PostChallenges(ctx, &ctfdcm.PostChallengesParams{
 		// CTFd
-		Name:           data.Name.ValueString(),
-		Category:       data.Category.ValueString(),
-		Description:    data.Description.ValueString(),
-		Attribution:    data.Attribution.ValueStringPointer(),
-		ConnectionInfo: data.ConnectionInfo.ValueStringPointer(),
-		MaxAttempts:    utils.ToInt(data.MaxAttempts),
-		Function:       data.Function.ValueStringPointer(),
-		Initial:        utils.ToInt(data.Value),
-		Decay:          utils.ToInt(data.Decay),
-		Minimum:        utils.ToInt(data.Minimum),
-		Logic:          data.Logic.ValueString(),
-		State:          data.State.ValueString(),
-		Position:       utils.ToInt(data.Position),
+		Name:           chall.Name.ValueString(),
+		Category:       chall.Category.ValueString(),
+		Description:    chall.Description.ValueString(),
+		Attribution:    chall.Attribution.ValueStringPointer(),
+		ConnectionInfo: chall.ConnectionInfo.ValueStringPointer(),
+		MaxAttempts:    utils.ToInt(chall.MaxAttempts),
+		Function:       chall.Function.ValueStringPointer(),
+		Initial:        utils.ToInt(chall.Value),
+		Decay:          utils.ToInt(chall.Decay),
+		Minimum:        utils.ToInt(chall.Minimum),
+		Logic:          chall.Logic.ValueString(),
+		State:          chall.State.ValueString(),
+		Position:       utils.ToInt(chall.Position),
 		Type:           "dynamic_iac",
-		NextID:         utils.ToInt(data.Next),
+		NextID:         utils.ToInt(chall.Next),
 		Requirements:   reqs,
 		// CTFd-Chall-Manager plugin
-		DestroyOnFlag: data.DestroyOnFlag.ValueBool(),
-		Shared:        data.Shared.ValueBool(),
-		ManaCost:      int(data.ManaCost.ValueInt64()),
-		Scenario:      data.Scenario.ValueString(),
-		Timeout:       utils.ToInt(data.Timeout),
-		Until:         data.Until.ValueStringPointer(),
+		DestroyOnFlag: chall.DestroyOnFlag.ValueBool(),
+		Shared:        chall.Shared.ValueBool(),
+		ManaCost:      int(chall.ManaCost.ValueInt64()),
+		Scenario:      fm.scenarioRef(chall.Scenario.ValueString()),
+		Timeout:       utils.ToInt(chall.Timeout),
+		Until:         chall.Until.ValueStringPointer(),
 		Additional:    add,
-		Min:           int(data.Min.ValueInt64()),
-		Max:           int(data.Max.ValueInt64()),
-	}, WithTracerProvider(r.fm.Tp))
+		Min:           int(chall.Min.ValueInt64()),
+		Max:           int(chall.Max.ValueInt64()),
+		FlagMode:      chall.FlagMode.ValueString(),
+	}, WithTracerProvider(fm.Tp))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to create challenge, got error: %s", err),
 		)
 		return
 	}
 
-	tflog.Trace(ctx, "created a challenge")
+	logTrace(ctx, "created a challenge")
 
 	// Save computed attributes in state
-	data.ID = types.StringValue(strconv.Itoa(res.ID))
+	chall.ID = types.StringValue(strconv.Itoa(res.ID))
 
-	// Create tags
-	challTags := make([]types.String, 0, len(data.Tags))
-	for _, tag := range data.Tags {
-		_, _, err := r.fm.Client.PostTags(ctx, &ctfd.PostTagsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
+	// Create tags, along with the provider default ones
+	challTags := make([]types.String, 0, len(chall.Tags))
+	for _, tag := range mergeDefaults(chall.Tags, fm.defaultTags()) {
+		_, _, err := fm.Client.PostTags(ctx, &ctfd.PostTagsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
 			Value:     tag.ValueString(),
-		}, WithTracerProvider(r.fm.Tp))
+		}, WithTracerProvider(fm.Tp))
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Client Error",
 				fmt.Sprintf("Unable to create tags, got error: %s", err),
 			)
@@ -166,20 +562,18 @@ func (r *challengeDynamicIaCResource) Create(ctx context.Context, req resource.C
 		}
 		challTags = append(challTags, tag)
 	}
-	if data.Tags != nil {
-		data.Tags = challTags
-	}
+	chall.TagsAll = stringSetValue(challTags)
 
-	// Create topics
-	challTopics := make([]types.String, 0, len(data.Topics))
-	for _, topic := range data.Topics {
-		_, _, err := r.fm.Client.PostTopics(ctx, &ctfd.PostTopicsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
+	// Create topics, along with the provider default ones
+	challTopics := make([]types.String, 0, len(chall.Topics))
+	for _, topic := range mergeDefaults(chall.Topics, fm.defaultTopics()) {
+		_, _, err := fm.Client.PostTopics(ctx, &ctfd.PostTopicsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
 			Type:      "challenge",
 			Value:     topic.ValueString(),
-		}, WithTracerProvider(r.fm.Tp))
+		}, WithTracerProvider(fm.Tp))
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Client Error",
 				fmt.Sprintf("Unable to create topic, got error: %s", err),
 			)
@@ -187,207 +581,144 @@ func (r *challengeDynamicIaCResource) Create(ctx context.Context, req resource.C
 		}
 		challTopics = append(challTopics, topic)
 	}
-	if data.Topics != nil {
-		data.Topics = challTopics
-	}
+	chall.TopicsAll = stringSetValue(challTopics)
 
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// Create flags, hints and files
+	chall.reconcileFlags(ctx, fm, nil, diags)
+	chall.reconcileHints(ctx, fm, nil, diags)
+	chall.reconcileFiles(ctx, fm, nil, diags)
 }
 
-func (r *challengeDynamicIaCResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
-	defer span.End()
-
-	var data ChallengeDynamicIaCResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	data.Read(ctx, r.fm.Client, resp.Diagnostics, WithTracerProvider(r.fm.Tp))
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
-
-func (r *challengeDynamicIaCResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
-	defer span.End()
-
-	var data ChallengeDynamicIaCResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	var dataState ChallengeDynamicIaCResourceModel
-	req.State.Get(ctx, &dataState)
-
+// update updates the challenge in CTFd, along with its subresources and the
+// provider default tags and topics. The type is only set when converting it.
+func (chall *ChallengeDynamicIaCModel) update(ctx context.Context, fm *Framework, prior *ChallengeDynamicIaCModel, typ *string, diags *diag.Diagnostics) {
 	// Patch direct attributes
-	reqs := (*ctfd.Requirements)(nil)
-	if data.Requirements != nil {
-		preqs := make([]int, 0, len(data.Requirements.Prerequisites))
-		for _, preq := range data.Requirements.Prerequisites {
-			id, _ := strconv.Atoi(preq.ValueString())
-			preqs = append(preqs, id)
-		}
-		reqs = &ctfd.Requirements{
-			Anonymize:     tfctfd.FromBehavior(data.Requirements.Behavior),
-			Prerequisites: preqs,
-		}
+	reqs := requirementsParams(chall.Requirements, diags)
+	if diags.HasError() {
+		return
 	}
 	add := map[string]string{}
-	for k, tv := range data.Additional.Elements() {
+	for k, tv := range chall.Additional.Elements() {
 		add[k] = tv.(types.String).ValueString()
 	}
-	if _, _, err := r.fm.Client.PatchChallenges(ctx, data.ID.ValueString(), &ctfdcm.PatchChallengeParams{
+	if _, _, err := fm.Client.PatchChallenges(ctx, chall.ID.ValueString(), &ctfdcm.PatchChallengeParams{
 		// CTFd
-		Name:           data.Name.ValueString(),
-		Category:       data.Category.ValueString(),
-		Description:    data.Description.ValueString(),
-		Attribution:    data.Attribution.ValueStringPointer(),
-		ConnectionInfo: data.ConnectionInfo.ValueStringPointer(),
-		MaxAttempts:    utils.ToInt(data.MaxAttempts),
-		Function:       data.Function.ValueStringPointer(),
-		Initial:        utils.ToInt(data.Value),
-		Decay:          utils.ToInt(data.Decay),
-		Minimum:        utils.ToInt(data.Minimum),
-		Logic:          data.Logic.ValueStringPointer(),
-		State:          data.State.ValueString(),
-		Position:       utils.ToInt(data.Position),
-		NextID:         utils.ToInt(data.Next),
+		Name:           chall.Name.ValueString(),
+		Category:       chall.Category.ValueString(),
+		Description:    chall.Description.ValueString(),
+		Attribution:    chall.Attribution.ValueStringPointer(),
+		ConnectionInfo: chall.ConnectionInfo.ValueStringPointer(),
+		MaxAttempts:    utils.ToInt(chall.MaxAttempts),
+		Function:       chall.Function.ValueStringPointer(),
+		Initial:        utils.ToInt(chall.Value),
+		Decay:          utils.ToInt(chall.Decay),
+		Minimum:        utils.ToInt(chall.Minimum),
+		Logic:          chall.Logic.ValueStringPointer(),
+		State:          chall.State.ValueString(),
+		Position:       utils.ToInt(chall.Position),
+		NextID:         utils.ToInt(chall.Next),
 		Requirements:   reqs,
+		Type:           typ,
 		// CTFd-Chall-Manager plugin
-		DestroyOnFlag: data.DestroyOnFlag.ValueBool(),
-		Shared:        data.Shared.ValueBool(),
-		ManaCost:      int(data.ManaCost.ValueInt64()),
-		Scenario:      data.Scenario.ValueString(),
-		Timeout:       utils.ToInt(data.Timeout),
-		Until:         data.Until.ValueStringPointer(),
+		DestroyOnFlag: chall.DestroyOnFlag.ValueBool(),
+		Shared:        chall.Shared.ValueBool(),
+		ManaCost:      int(chall.ManaCost.ValueInt64()),
+		Scenario:      fm.scenarioRef(chall.Scenario.ValueString()),
+		Timeout:       utils.ToInt(chall.Timeout),
+		Until:         chall.Until.ValueStringPointer(),
 		Additional:    add,
-		Min:           int(data.Min.ValueInt64()),
-		Max:           int(data.Max.ValueInt64()),
-	}, WithTracerProvider(r.fm.Tp)); err != nil {
-		resp.Diagnostics.AddError(
+		Min:           int(chall.Min.ValueInt64()),
+		Max:           int(chall.Max.ValueInt64()),
+		FlagMode:      chall.FlagMode.ValueString(),
+	}, WithTracerProvider(fm.Tp)); err != nil {
+		diags.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to update challenge, got error: %s", err),
 		)
 		return
 	}
 
-	// Update its tags (drop them all, create new ones)
-	challTags, _, err := r.fm.Client.GetChallengeTags(ctx, data.ID.ValueString(), WithTracerProvider(r.fm.Tp))
+	// Update its tags (drop them all, create new ones along with the provider default ones)
+	challTags, _, err := fm.Client.GetChallengeTags(ctx, chall.ID.ValueString(), WithTracerProvider(fm.Tp))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Client Error",
-			fmt.Sprintf("Unable to get all tags of challenge %s, got error: %s", data.ID.ValueString(), err),
+			fmt.Sprintf("Unable to get all tags of challenge %s, got error: %s", chall.ID.ValueString(), err),
 		)
 		return
 	}
 	for _, tag := range challTags {
-		if _, err := r.fm.Client.DeleteTag(ctx, strconv.Itoa(tag.ID), WithTracerProvider(r.fm.Tp)); err != nil {
-			resp.Diagnostics.AddError(
+		if _, err := fm.Client.DeleteTag(ctx, strconv.Itoa(tag.ID), WithTracerProvider(fm.Tp)); err != nil {
+			diags.AddError(
 				"Client Error",
-				fmt.Sprintf("Unable to delete tag %d of challenge %s, got error: %s", tag.ID, data.ID.ValueString(), err),
+				fmt.Sprintf("Unable to delete tag %d of challenge %s, got error: %s", tag.ID, chall.ID.ValueString(), err),
 			)
 			return
 		}
 	}
-	tags := make([]types.String, 0, len(data.Tags))
-	for _, tag := range data.Tags {
-		_, _, err := r.fm.Client.PostTags(ctx, &ctfd.PostTagsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
+	tags := make([]types.String, 0, len(chall.Tags))
+	for _, tag := range mergeDefaults(chall.Tags, fm.defaultTags()) {
+		_, _, err := fm.Client.PostTags(ctx, &ctfd.PostTagsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
 			Value:     tag.ValueString(),
-		}, WithTracerProvider(r.fm.Tp))
+		}, WithTracerProvider(fm.Tp))
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Client Error",
-				fmt.Sprintf("Unable to create tag of challenge %s, got error: %s", data.ID.ValueString(), err),
+				fmt.Sprintf("Unable to create tag of challenge %s, got error: %s", chall.ID.ValueString(), err),
 			)
 			return
 		}
 		tags = append(tags, tag)
 	}
-	if data.Tags != nil {
-		data.Tags = tags
-	}
+	chall.TagsAll = stringSetValue(tags)
 
-	// Update its topics (drop them all, create new ones)
-	challTopics, _, err := r.fm.Client.GetChallengeTopics(ctx, data.ID.ValueString(), WithTracerProvider(r.fm.Tp))
+	// Update its topics (drop them all, create new ones along with the provider default ones)
+	challTopics, _, err := fm.Client.GetChallengeTopics(ctx, chall.ID.ValueString(), WithTracerProvider(fm.Tp))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Client Error",
-			fmt.Sprintf("Unable to get all topics of challenge %s, got error: %s", data.ID.ValueString(), err),
+			fmt.Sprintf("Unable to get all topics of challenge %s, got error: %s", chall.ID.ValueString(), err),
 		)
 		return
 	}
 	for _, topic := range challTopics {
-		if _, err := r.fm.Client.DeleteTopic(ctx, &ctfd.DeleteTopicArgs{
+		if _, err := fm.Client.DeleteTopic(ctx, &ctfd.DeleteTopicArgs{
 			ID:   strconv.Itoa(topic.ID),
 			Type: "challenge",
-		}, WithTracerProvider(r.fm.Tp)); err != nil {
-			resp.Diagnostics.AddError(
+		}, WithTracerProvider(fm.Tp)); err != nil {
+			diags.AddError(
 				"Client Error",
-				fmt.Sprintf("Unable to delete topic %d of challenge %s, got error: %s", topic.ID, data.ID.ValueString(), err),
+				fmt.Sprintf("Unable to delete topic %d of challenge %s, got error: %s", topic.ID, chall.ID.ValueString(), err),
 			)
 			return
 		}
 	}
-	topics := make([]types.String, 0, len(data.Topics))
-	for _, topic := range data.Topics {
-		_, _, err := r.fm.Client.PostTopics(ctx, &ctfd.PostTopicsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
+	topics := make([]types.String, 0, len(chall.Topics))
+	for _, topic := range mergeDefaults(chall.Topics, fm.defaultTopics()) {
+		_, _, err := fm.Client.PostTopics(ctx, &ctfd.PostTopicsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
 			Type:      "challenge",
 			Value:     topic.ValueString(),
-		}, WithTracerProvider(r.fm.Tp))
+		}, WithTracerProvider(fm.Tp))
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Client Error",
-				fmt.Sprintf("Unable to create topic of challenge %s, got error: %s", data.ID.ValueString(), err),
+				fmt.Sprintf("Unable to create topic of challenge %s, got error: %s", chall.ID.ValueString(), err),
 			)
 			return
 		}
 		topics = append(topics, topic)
 	}
-	if data.Topics != nil {
-		data.Topics = topics
-	}
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
-
-func (r *challengeDynamicIaCResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
-	defer span.End()
-
-	var data ChallengeDynamicIaCResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	if _, err := r.fm.Client.DeleteChallenge(ctx, data.ID.ValueString(), WithTracerProvider(r.fm.Tp)); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
-		return
-	}
+	chall.TopicsAll = stringSetValue(topics)
 
-	// ... don't need to delete nested objects, this is handled by CTFd
-}
-
-func (r *challengeDynamicIaCResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-
-	// Automatically call r.Read
+	// Update its flags, hints and files against the prior ones
+	chall.reconcileFlags(ctx, fm, prior.Flags, diags)
+	chall.reconcileHints(ctx, fm, prior.Hints, diags)
+	chall.reconcileFiles(ctx, fm, prior.Files, diags)
 }
 
-func (chall *ChallengeDynamicIaCResourceModel) Read(ctx context.Context, client *Client, diags diag.Diagnostics, opts ...Option) {
+func (chall *ChallengeDynamicIaCModel) Read(ctx context.Context, client *Client, diags *diag.Diagnostics, opts ...Option) {
 	res, _, err := client.GetChallenge(ctx, chall.ID.ValueString(), opts...)
 	if err != nil {
 		diags.AddError("Client Error", fmt.Sprintf("Unable to read challenge %s, got error: %s", chall.ID.ValueString(), err))
@@ -413,7 +744,8 @@ func (chall *ChallengeDynamicIaCResourceModel) Read(ctx context.Context, client
 	chall.Shared = types.BoolValue(res.Shared)
 	chall.ManaCost = types.Int64Value(int64(res.ManaCost))
 	chall.Scenario = types.StringValue(res.Scenario)
-	chall.Timeout = utils.ToTFInt64(res.Timeout)
+	chall.Timeout = readTimeout(chall.Timeout, res.Timeout)
+	chall.Until = types.StringNull()
 	if res.Until != nil && *res.Until != "" { // cannot use utils.ToTFString due to ctfer-io/ctfd-chall-manager#163
 		chall.Until = types.StringValue(*res.Until)
 	}
@@ -426,6 +758,11 @@ func (chall *ChallengeDynamicIaCResourceModel) Read(ctx context.Context, client
 	chall.Additional = add
 	chall.Min = types.Int64Value(int64(res.Min))
 	chall.Max = types.Int64Value(int64(res.Max))
+	chall.FlagMode = types.StringValue(res.FlagMode)
+	if res.FlagMode == "" {
+		// Challenges created before the instance flags only validate static ones
+		chall.FlagMode = types.StringValue(flagModeStatic)
+	}
 
 	// Get subresources
 	// => Requirements
@@ -463,6 +800,7 @@ func (chall *ChallengeDynamicIaCResourceModel) Read(ctx context.Context, client
 	for _, tag := range resTags {
 		chall.Tags = append(chall.Tags, types.StringValue(tag.Value))
 	}
+	chall.TagsAll = stringSetValue(chall.Tags)
 
 	// => Topics
 	resTopics, _, err := client.GetChallengeTopics(ctx, chall.ID.ValueString(), opts...)
@@ -477,10 +815,16 @@ func (chall *ChallengeDynamicIaCResourceModel) Read(ctx context.Context, client
 	for _, topic := range resTopics {
 		chall.Topics = append(chall.Topics, types.StringValue(topic.Value))
 	}
+	chall.TopicsAll = stringSetValue(chall.Topics)
+
+	// => Flags, hints and files
+	chall.readFlags(ctx, client, diags, opts...)
+	chall.readHints(ctx, client, diags, opts...)
+	chall.readFiles(ctx, client, diags, opts...)
 }
 
 var (
-	ChallengeDynamicIaCResourceAttributes = utils.BlindMerge(tfctfd.ChallengeDynamicResourceAttributes, map[string]schema.Attribute{
+	ChallengeDynamicIaCResourceAttributes = utils.BlindMerge(utils.BlindMerge(tfctfd.ChallengeDynamicResourceAttributes, challengeSubresourcesAttributes), map[string]schema.Attribute{
 		"shared": schema.BoolAttribute{
 			MarkdownDescription: "Whether the instance will be shared between all players.",
 			Optional:            true,
@@ -499,13 +843,23 @@ var (
 			Computed:            true,
 			Default:             defaults.Int64(int64default.StaticInt64(0)),
 		},
+		"flag_mode": schema.StringAttribute{
+			MarkdownDescription: "How submissions are validated, either `static` (against the challenge flags), `instance` (against the flag returned by the instance scenario) or `both`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             defaults.String(stringdefault.StaticString(flagModeStatic)),
+			Validators: []validator.String{
+				stringvalidator.OneOf(flagModes...),
+			},
+		},
 		"scenario": schema.StringAttribute{
-			MarkdownDescription: "The OCI reference to the scenario.",
+			MarkdownDescription: "The OCI reference to the scenario. If the provider `scenario_registry` is set, it could be a short `name:tag` reference, completed with it.",
 			Required:            true,
 		},
 		"timeout": schema.Int64Attribute{
-			MarkdownDescription: "The timeout (in seconds) after which the instance will be janitored.",
+			MarkdownDescription: "The timeout (in seconds) after which the instance will be janitored. A zero timeout is considered as no timeout.",
 			Optional:            true,
+			Computed:            true,
 		},
 		"until": schema.StringAttribute{
 			MarkdownDescription: "The date until the instance could run before being janitored.",
@@ -530,5 +884,57 @@ var (
 			Computed:            true,
 			Default:             defaults.Int64(int64default.StaticInt64(0)),
 		},
+		"prewarm_on_update": schema.BoolAttribute{
+			MarkdownDescription: "Whether to pre-warm the instances pool on update, i.e. wait until `min` instances are ready. It is not stored in CTFd.",
+			Optional:            true,
+			Computed:            true,
+			Default:             defaults.Bool(booldefault.StaticBool(false)),
+		},
+		"prewarm_timeout": schema.Int64Attribute{
+			MarkdownDescription: "The timeout (in seconds) to wait for the pool to be pre-warmed on update. Defaults to 600. It is not stored in CTFd.",
+			Optional:            true,
+		},
+		"tags_all": schema.SetAttribute{
+			MarkdownDescription: "All the tags of the challenge, including the provider `default_tags`.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"topics_all": schema.SetAttribute{
+			MarkdownDescription: "All the topics of the challenge, including the provider `default_topics`.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	})
+)
+
+// challengeDynamicIaCResourceModelV0 is the model of the schema version 0.
+// It must not change, as it is used to upgrade states.
+type challengeDynamicIaCResourceModelV0 struct {
+	tfctfd.ChallengeDynamicResourceModel
+
+	Shared        types.Bool   `tfsdk:"shared"`
+	DestroyOnFlag types.Bool   `tfsdk:"destroy_on_flag"`
+	ManaCost      types.Int64  `tfsdk:"mana_cost"`
+	Scenario      types.String `tfsdk:"scenario"`
+	Timeout       types.Int64  `tfsdk:"timeout"`
+	Until         types.String `tfsdk:"until"`
+	Additional    types.Map    `tfsdk:"additional"`
+	Min           types.Int64  `tfsdk:"min"`
+	Max           types.Int64  `tfsdk:"max"`
+}
+
+var (
+	// challengeDynamicIaCResourceAttributesV0 is the schema version 0, only used to
+	// decode prior states thus only types matter.
+	challengeDynamicIaCResourceAttributesV0 = utils.BlindMerge(tfctfd.ChallengeDynamicResourceAttributes, map[string]schema.Attribute{
+		"shared":          schema.BoolAttribute{Optional: true},
+		"destroy_on_flag": schema.BoolAttribute{Optional: true},
+		"mana_cost":       schema.Int64Attribute{Optional: true},
+		"scenario":        schema.StringAttribute{Required: true},
+		"timeout":         schema.Int64Attribute{Optional: true},
+		"until":           schema.StringAttribute{Optional: true},
+		"additional":      schema.MapAttribute{ElementType: types.StringType, Optional: true},
+		"min":             schema.Int64Attribute{Optional: true},
+		"max":             schema.Int64Attribute{Optional: true},
 	})
 )
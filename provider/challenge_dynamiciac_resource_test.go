@@ -1,10 +1,20 @@
 package provider_test
 
 import (
+	"context"
+	"path"
+	"regexp"
 	"testing"
 
+	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAcc_ChallengeDynamicIaC_Lifecycle(t *testing.T) {
@@ -108,3 +118,1032 @@ variable "scenario" {
 		},
 	})
 }
+
+func TestAcc_ChallengeDynamicIaC_PrewarmOnUpdate(t *testing.T) {
+	cfg := providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "pooled" {
+	name        = "Some pooled challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+	min      = var.min
+	max      = 2
+
+	prewarm_on_update = true
+	prewarm_timeout   = 60
+}
+
+variable "scenario" {
+  type = string
+}
+
+variable "min" {
+  type = number
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"min":      config.IntegerVariable(0),
+				},
+			},
+			// The pool is pre-warmed once updated, a failure only warning
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"min":      config.IntegerVariable(1),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_challenge_dynamiciac.pooled", "min", "1"),
+					resource.TestCheckResourceAttr("ctfdcm_challenge_dynamiciac.pooled", "prewarm_timeout", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_ZeroTimeout(t *testing.T) {
+	cfg := providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+	timeout  = 0
+}
+
+variable "scenario" {
+  type = string
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_challenge_dynamiciac.http", "timeout", "0"),
+				),
+			},
+			// An explicit zero timeout must not produce changes
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_InvalidFlagMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario  = "localhost:5000/some/scenario:v0.1.0"
+	flag_mode = "dynamic"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_InvalidPrerequisite(t *testing.T) {
+	// Prerequisites must be challenge identifiers, even without requirements check
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = "localhost:5000/some/scenario:v0.1.0"
+
+	requirements = {
+		prerequisites = ["first"]
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Prerequisite`),
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_MoveState(t *testing.T) {
+	moved := providerConfig + `
+moved {
+	from = ctfd_challenge_dynamic.http
+	to   = ctfdcm_challenge_dynamiciac.http
+}
+
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+
+	tags = [
+		"network"
+	]
+}
+
+variable "scenario" {
+  type = string
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			// Create a dynamic challenge through the CTFd provider
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_dynamic" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	tags = [
+		"network"
+	]
+}
+`,
+			},
+			// Move it to a dynamic_iac challenge, which must be updated in place
+			{
+				Config: moved,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ctfdcm_challenge_dynamiciac.http", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ctfdcm_challenge_dynamiciac.http", "id"),
+					resource.TestCheckResourceAttr("ctfdcm_challenge_dynamiciac.http", "scenario", ref),
+				),
+			},
+			// Once the scenario is set, the moved challenge must converge
+			{
+				Config: moved,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ChallengeDynamicIaC_UpgradeStateV0(t *testing.T) {
+	state := upgradeState(t, provider.NewChallengeDynamicIaCResource(), 0, "challenge_dynamiciac_v0.json")
+
+	var data provider.ChallengeDynamicIaCResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("decoding upgraded state: %v", diags)
+	}
+
+	if data.ID.ValueString() != "1" {
+		t.Errorf("expected id 1, got %s", data.ID)
+	}
+	if data.Scenario.ValueString() != "localhost:5000/scenario:v0.1.0" {
+		t.Errorf("expected scenario to be kept, got %s", data.Scenario)
+	}
+	if data.Timeout.ValueInt64() != 600 {
+		t.Errorf("expected timeout 600, got %s", data.Timeout)
+	}
+	if !data.Until.IsNull() {
+		t.Errorf("expected until to be null, got %s", data.Until)
+	}
+	if data.Additional.IsNull() || len(data.Additional.Elements()) != 0 {
+		t.Errorf("expected additional to be an empty map, got %s", data.Additional)
+	}
+}
+
+func TestAcc_ChallengeDynamicIaC_Identity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("id")),
+				},
+			},
+			// Import through identity
+			{
+				ResourceName:    "ctfdcm_challenge_dynamiciac.http",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_GenerateConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+			// Import with a configuration generated from the state, which must not produce changes
+			{
+				ResourceName:    "ctfdcm_challenge_dynamiciac.http",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ctfdcm_challenge_dynamiciac.http", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_ProviderDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "ctfdcm" {
+	scenario_registry = var.registry
+
+	defaults = {
+		timeout         = 600
+		mana_cost       = 1
+		destroy_on_flag = true
+	}
+}
+
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario  = var.scenario
+	mana_cost = 2
+}
+
+variable "registry" {
+  type = string
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"registry": config.StringVariable(path.Dir(ref)),
+					"scenario": config.StringVariable(path.Base(ref)),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("timeout"), knownvalue.Int64Exact(600)),
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("mana_cost"), knownvalue.Int64Exact(2)),
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("destroy_on_flag"), knownvalue.Bool(true)),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("timeout"), knownvalue.Int64Exact(600)),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("mana_cost"), knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("destroy_on_flag"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("scenario"), knownvalue.StringExact(path.Base(ref))),
+				},
+			},
+			// The short scenario reference must not produce changes
+			{
+				Config: `
+provider "ctfdcm" {
+	scenario_registry = var.registry
+
+	defaults = {
+		timeout         = 600
+		mana_cost       = 1
+		destroy_on_flag = true
+	}
+}
+
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario  = var.scenario
+	mana_cost = 2
+}
+
+variable "registry" {
+  type = string
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"registry": config.StringVariable(path.Dir(ref)),
+					"scenario": config.StringVariable(path.Base(ref)),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_DefaultTagsTopics(t *testing.T) {
+	cfg := `
+provider "ctfdcm" {
+	default_tags   = [var.edition]
+	default_topics = ["Infrastructure"]
+}
+
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+
+	topics = [
+		"Network"
+	]
+	tags = [
+		"network"
+	]
+}
+
+variable "scenario" {
+  type = string
+}
+
+variable "edition" {
+  type = string
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"edition":  config.StringVariable("2026"),
+				},
+				// The merged entries must be known at plan time, as they are stored on create
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("tags_all"), knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("network"),
+							knownvalue.StringExact("2026"),
+						})),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("tags"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("network"),
+					})),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("tags_all"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("network"),
+						knownvalue.StringExact("2026"),
+					})),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("topics_all"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("Network"),
+						knownvalue.StringExact("Infrastructure"),
+					})),
+				},
+			},
+			// The merged entries must not produce changes
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"edition":  config.StringVariable("2026"),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Changing the provider defaults only updates the merged entries
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"edition":  config.StringVariable("2027"),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ctfdcm_challenge_dynamiciac.http", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("tags_all"), knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("network"),
+							knownvalue.StringExact("2027"),
+						})),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("tags"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("network"),
+					})),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("tags_all"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("network"),
+						knownvalue.StringExact("2027"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_Subresources(t *testing.T) {
+	cfg := `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+
+	flags = [{
+		content = var.flag
+	}]
+	hints = var.hints
+	files = [{
+		name           = "note.txt"
+		content_base64 = base64encode(var.note)
+	}]
+}
+
+variable "scenario" {
+  type = string
+}
+
+variable "flag" {
+  type = string
+}
+
+variable "hints" {
+  type = list(object({
+    content      = string
+    cost         = number
+    requirements = optional(list(number))
+  }))
+}
+
+variable "note" {
+  type = string
+}
+`
+	hints := config.ListVariable(
+		config.ObjectVariable(map[string]config.Variable{
+			"content": config.StringVariable("Look at the headers"),
+			"cost":    config.IntegerVariable(10),
+		}),
+		config.ObjectVariable(map[string]config.Variable{
+			"content":      config.StringVariable("It is basic"),
+			"cost":         config.IntegerVariable(50),
+			"requirements": config.ListVariable(config.IntegerVariable(0)),
+		}),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Hint requirements must refer to previous hints
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"flag":     config.StringVariable("CTF{some_flag}"),
+					"hints": config.ListVariable(
+						config.ObjectVariable(map[string]config.Variable{
+							"content":      config.StringVariable("Look at the headers"),
+							"cost":         config.IntegerVariable(10),
+							"requirements": config.ListVariable(config.IntegerVariable(1)),
+						}),
+						config.ObjectVariable(map[string]config.Variable{
+							"content": config.StringVariable("It is basic"),
+							"cost":    config.IntegerVariable(50),
+						}),
+					),
+					"note": config.StringVariable("Some note to start with."),
+				},
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Hint Requirement`),
+			},
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"flag":     config.StringVariable("CTF{some_flag}"),
+					"hints":    hints,
+					"note":     config.StringVariable("Some note to start with."),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("flags").AtSliceIndex(0).AtMapKey("type"), knownvalue.StringExact("static")),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("hints").AtSliceIndex(1).AtMapKey("requirements"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Int64Exact(0),
+					})),
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("files").AtSliceIndex(0).AtMapKey("location"), knownvalue.NotNull()),
+				},
+			},
+			// Read back flags, hints and files must not produce changes
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"flag":     config.StringVariable("CTF{some_flag}"),
+					"hints":    hints,
+					"note":     config.StringVariable("Some note to start with."),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Changes are reconciled in place, and a changed file is uploaded again
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"flag":     config.StringVariable("CTF{some_other_flag}"),
+					"hints": config.ListVariable(
+						config.ObjectVariable(map[string]config.Variable{
+							"content": config.StringVariable("Look at the headers"),
+							"cost":    config.IntegerVariable(20),
+						}),
+					),
+					"note": config.StringVariable("Some other note."),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ctfdcm_challenge_dynamiciac.http", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("files").AtSliceIndex(0).AtMapKey("id")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("hints"), knownvalue.ListSizeExact(1)),
+				},
+			},
+			// Inserting a hint keeps the following ones, as they are matched by content
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"flag":     config.StringVariable("CTF{some_other_flag}"),
+					"hints": config.ListVariable(
+						config.ObjectVariable(map[string]config.Variable{
+							"content": config.StringVariable("Check the robots"),
+							"cost":    config.IntegerVariable(5),
+						}),
+						config.ObjectVariable(map[string]config.Variable{
+							"content": config.StringVariable("Look at the headers"),
+							"cost":    config.IntegerVariable(20),
+						}),
+					),
+					"note": config.StringVariable("Some other note."),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ctfdcm_challenge_dynamiciac.http", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("hints").AtSliceIndex(0).AtMapKey("id")),
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("hints").AtSliceIndex(1).AtMapKey("id"), knownvalue.NotNull()),
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("flags").AtSliceIndex(0).AtMapKey("id"), knownvalue.NotNull()),
+						plancheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("files").AtSliceIndex(0).AtMapKey("id"), knownvalue.NotNull()),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_SubresourcesAside(t *testing.T) {
+	cfg := providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+resource "ctfd_flag" "http" {
+	challenge_id = ctfdcm_challenge_dynamiciac.http.id
+	content      = "CTF{some_flag}"
+}
+
+variable "scenario" {
+  type = string
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("flags"), knownvalue.Null()),
+				},
+			},
+			// Flags managed aside must not be tracked by the challenge
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.http", tfjsonpath.New("flags"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_Requirements(t *testing.T) {
+	cfg := `
+provider "ctfdcm" {
+	requirements_check = true
+}
+
+resource "ctfdcm_challenge_dynamiciac" "first" {
+	name        = "First"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+resource "ctfdcm_challenge_dynamiciac" "second" {
+	name        = "Second"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+
+	requirements = {
+		prerequisites = concat([ctfdcm_challenge_dynamiciac.first.id], var.prerequisites)
+	}
+	next = var.next_first ? ctfdcm_challenge_dynamiciac.first.id : null
+}
+
+variable "scenario" {
+  type = string
+}
+
+variable "prerequisites" {
+  type    = list(string)
+  default = []
+}
+
+variable "next_first" {
+  type    = bool
+  default = false
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+			// Prerequisites must be challenge identifiers
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario":      config.StringVariable(ref),
+					"prerequisites": config.ListVariable(config.StringVariable("first")),
+				},
+				ExpectError: regexp.MustCompile(`Invalid Prerequisite`),
+			},
+			// Prerequisites must exist
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario":      config.StringVariable(ref),
+					"prerequisites": config.ListVariable(config.StringVariable("999999")),
+				},
+				ExpectError: regexp.MustCompile(`Missing Prerequisite`),
+			},
+			// The first challenge could not be both required and next
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario":   config.StringVariable(ref),
+					"next_first": config.BoolVariable(true),
+				},
+				ExpectError: regexp.MustCompile(`Requirements Cycle`),
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_RequirementsChain(t *testing.T) {
+	// The prerequisites are only known once created during the apply, so after the
+	// requirements graph is loaded
+	cfg := `
+provider "ctfdcm" {
+	requirements_check = true
+}
+
+resource "ctfdcm_challenge_dynamiciac" "a" {
+	name        = "A"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+}
+
+resource "ctfdcm_challenge_dynamiciac" "b" {
+	name        = "B"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+
+	requirements = {
+		prerequisites = var.b_requires_a ? [ctfdcm_challenge_dynamiciac.a.id] : []
+	}
+}
+
+resource "ctfdcm_challenge_dynamiciac" "c" {
+	name        = "C"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario = var.scenario
+
+	requirements = {
+		prerequisites = [ctfdcm_challenge_dynamiciac.b.id]
+	}
+	next = var.next_a ? ctfdcm_challenge_dynamiciac.a.id : null
+}
+
+variable "scenario" {
+  type = string
+}
+
+variable "next_a" {
+  type    = bool
+  default = false
+}
+
+variable "b_requires_a" {
+  type    = bool
+  default = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"ctfdcm_challenge_dynamiciac.c", tfjsonpath.New("requirements").AtMapKey("prerequisites").AtSliceIndex(0),
+						"ctfdcm_challenge_dynamiciac.b", tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// A is solved before C through B, so could not be suggested after it
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+					"next_a":   config.BoolVariable(true),
+				},
+				ExpectError: regexp.MustCompile(`Requirements Cycle`),
+			},
+			// Unless B no longer requires A in the same plan
+			{
+				Config: cfg,
+				ConfigVariables: config.Variables{
+					"scenario":     config.StringVariable(ref),
+					"next_a":       config.BoolVariable(true),
+					"b_requires_a": config.BoolVariable(false),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.c", tfjsonpath.New("next"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_ChallengeDynamicIaC_ManaBudgetCheck(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Exceeding the mana total only warns, so it must still apply
+			{
+				Config: `
+provider "ctfdcm" {
+	mana_budget_check = true
+}
+
+resource "ctfdcm_challenge_dynamiciac" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "hidden"
+
+	scenario  = var.scenario
+	mana_cost = 100
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_challenge_dynamiciac.http", "mana_cost", "100"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,615 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfd "github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+type ChallengeFlagModel struct {
+	ID      types.String `tfsdk:"id"`
+	Content types.String `tfsdk:"content"`
+	Data    types.String `tfsdk:"data"`
+	Type    types.String `tfsdk:"type"`
+}
+
+type ChallengeHintModel struct {
+	ID           types.String  `tfsdk:"id"`
+	Content      types.String  `tfsdk:"content"`
+	Cost         types.Int64   `tfsdk:"cost"`
+	Requirements []types.Int64 `tfsdk:"requirements"`
+}
+
+type ChallengeFileModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Path          types.String `tfsdk:"path"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	SHA256        types.String `tfsdk:"sha256"`
+	Location      types.String `tfsdk:"location"`
+}
+
+// challengeSubresourcesAttributes are the flags, hints and files inlined in the challenge.
+// As CTFd identifies them on its own, they are reconciled by identifier: the ones with
+// an unknown identifier are created, the known ones are updated if they changed, and
+// the ones that disappeared are deleted. Identifiers are kept at plan time from the
+// prior elements with the same content, see matchSubresources.
+var challengeSubresourcesAttributes = map[string]schema.Attribute{
+	"flags": schema.ListNestedAttribute{
+		MarkdownDescription: "The flags of the challenge.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Identifier of the flag.",
+					Computed:            true,
+				},
+				"content": schema.StringAttribute{
+					MarkdownDescription: "The actual flag, or the regular expression to match it.",
+					Required:            true,
+					Sensitive:           true,
+				},
+				"data": schema.StringAttribute{
+					MarkdownDescription: "Either `case_sensitive` or `case_insensitive`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             defaults.String(stringdefault.StaticString("case_sensitive")),
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Either `static` or `regex`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             defaults.String(stringdefault.StaticString("static")),
+				},
+			},
+		},
+	},
+	"hints": schema.ListNestedAttribute{
+		MarkdownDescription: "The hints of the challenge.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Identifier of the hint.",
+					Computed:            true,
+				},
+				"content": schema.StringAttribute{
+					MarkdownDescription: "Content of the hint as displayed to the end-user.",
+					Required:            true,
+				},
+				"cost": schema.Int64Attribute{
+					MarkdownDescription: "Cost (in points) of the hint to unlock it.",
+					Optional:            true,
+					Computed:            true,
+					Default:             defaults.Int64(int64default.StaticInt64(0)),
+				},
+				"requirements": schema.ListAttribute{
+					MarkdownDescription: "Indexes of the previous hints of the challenge to unlock before this one.",
+					ElementType:         types.Int64Type,
+					Optional:            true,
+				},
+			},
+		},
+	},
+	"files": schema.ListNestedAttribute{
+		MarkdownDescription: "The files of the challenge, given either by `path` or `content_base64`. Changes are tracked through their SHA256, and a changed file is uploaded again.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Identifier of the file.",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the file as displayed to the end-user.",
+					Required:            true,
+				},
+				"path": schema.StringAttribute{
+					MarkdownDescription: "Path to the file to upload. Conflicts with `content_base64`.",
+					Optional:            true,
+				},
+				"content_base64": schema.StringAttribute{
+					MarkdownDescription: "Base64-encoded content of the file to upload. Conflicts with `path`.",
+					Optional:            true,
+				},
+				"sha256": schema.StringAttribute{
+					MarkdownDescription: "The SHA256 sum of the file content.",
+					Computed:            true,
+					PlanModifiers: []planmodifier.String{
+						fileSHA256{},
+					},
+				},
+				"location": schema.StringAttribute{
+					MarkdownDescription: "Location of the file in CTFd.",
+					Computed:            true,
+				},
+			},
+		},
+	},
+}
+
+// matchSubresources keeps the identifiers of the planned flags, hints and files from
+// the prior ones with the same content. They are matched by content rather than by
+// position, such that inserting or removing one does not recreate the following ones.
+func matchSubresources(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	matchPrior(ctx, req, resp, "flags", func(f, p ChallengeFlagModel) bool {
+		return f.Content.Equal(p.Content)
+	}, func(f *ChallengeFlagModel, p ChallengeFlagModel) {
+		f.ID = p.ID
+	})
+	matchPrior(ctx, req, resp, "hints", func(h, p ChallengeHintModel) bool {
+		return h.Content.Equal(p.Content)
+	}, func(h *ChallengeHintModel, p ChallengeHintModel) {
+		h.ID = p.ID
+	})
+	// Files could not be updated, so they are only kept if unchanged
+	matchPrior(ctx, req, resp, "files", func(f, p ChallengeFileModel) bool {
+		return f.Name.Equal(p.Name) && f.SHA256.Equal(p.SHA256)
+	}, func(f *ChallengeFileModel, p ChallengeFileModel) {
+		f.ID = p.ID
+		f.Location = p.Location
+	})
+}
+
+// matchPrior keeps the values of the planned elements of a list attribute from the
+// first prior element they match, each prior element being matched at most once.
+func matchPrior[T any](ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, name string, match func(v, p T) bool, keep func(v *T, p T)) {
+	var planned, prior types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() || prior.IsNull() {
+		return
+	}
+
+	// Elements with unknown collections (e.g. hint requirements) could not be
+	// decoded, in which case they are all left to be reconciled at apply time
+	var values, priors []T
+	if diags := planned.ElementsAs(ctx, &values, false); diags.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(prior.ElementsAs(ctx, &priors, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matched := make([]bool, len(priors))
+	for i := range values {
+		for j, p := range priors {
+			if !matched[j] && match(values[i], p) {
+				matched[j] = true
+				keep(&values[i], p)
+				break
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), values)...)
+}
+
+// region flags
+
+func (chall *ChallengeDynamicIaCModel) reconcileFlags(ctx context.Context, fm *Framework, prior []ChallengeFlagModel, diags *diag.Diagnostics) {
+	// Do not go further if a previous step failed
+	if diags.HasError() {
+		return
+	}
+
+	for _, flag := range prior {
+		if !slices.ContainsFunc(chall.Flags, func(f ChallengeFlagModel) bool { return f.ID.Equal(flag.ID) }) {
+			if _, err := fm.Client.DeleteFlag(ctx, flag.ID.ValueString(), WithTracerProvider(fm.Tp)); err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to delete flag %s of challenge %s, got error: %s", flag.ID.ValueString(), chall.ID.ValueString(), err),
+				)
+				return
+			}
+		}
+	}
+
+	for i, flag := range chall.Flags {
+		if flag.ID.IsUnknown() || flag.ID.IsNull() {
+			res, _, err := fm.Client.PostFlags(ctx, &ctfd.PostFlagsParams{
+				Challenge: utils.Atoi(chall.ID.ValueString()),
+				Content:   flag.Content.ValueString(),
+				Data:      flag.Data.ValueString(),
+				Type:      flag.Type.ValueString(),
+			}, WithTracerProvider(fm.Tp))
+			if err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to create flag of challenge %s, got error: %s", chall.ID.ValueString(), err),
+				)
+				return
+			}
+			chall.Flags[i].ID = types.StringValue(strconv.Itoa(res.ID))
+			continue
+		}
+
+		if slices.Contains(prior, flag) {
+			continue
+		}
+		if _, _, err := fm.Client.PatchFlag(ctx, flag.ID.ValueString(), &ctfd.PatchFlagParams{
+			ID:      flag.ID.ValueString(),
+			Content: flag.Content.ValueString(),
+			Data:    flag.Data.ValueString(),
+			Type:    flag.Type.ValueString(),
+		}, WithTracerProvider(fm.Tp)); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to update flag %s of challenge %s, got error: %s", flag.ID.ValueString(), chall.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+}
+
+func (chall *ChallengeDynamicIaCModel) readFlags(ctx context.Context, client *Client, diags *diag.Diagnostics, opts ...Option) {
+	resFlags, _, err := client.GetChallengeFlags(ctx, chall.ID.ValueString(), opts...)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read challenge %s flags, got error: %s", chall.ID.ValueString(), err),
+		)
+		return
+	}
+	if chall.Flags == nil && len(resFlags) == 0 {
+		return
+	}
+
+	flags := make([]ChallengeFlagModel, 0, len(resFlags))
+	for _, flag := range resFlags {
+		flags = append(flags, ChallengeFlagModel{
+			ID:      types.StringValue(strconv.Itoa(flag.ID)),
+			Content: types.StringValue(flag.Content),
+			Data:    types.StringValue(flag.Data),
+			Type:    types.StringValue(flag.Type),
+		})
+	}
+	chall.Flags = orderAsPrior(flags, chall.Flags, func(f ChallengeFlagModel) types.String { return f.ID }, func(f ChallengeFlagModel) types.String { return f.ID })
+}
+
+// region hints
+
+func (chall *ChallengeDynamicIaCModel) reconcileHints(ctx context.Context, fm *Framework, prior []ChallengeHintModel, diags *diag.Diagnostics) {
+	// Do not go further if a previous step failed
+	if diags.HasError() {
+		return
+	}
+
+	for _, hint := range prior {
+		if !slices.ContainsFunc(chall.Hints, func(h ChallengeHintModel) bool { return h.ID.Equal(hint.ID) }) {
+			if _, err := fm.Client.DeleteHint(ctx, hint.ID.ValueString(), WithTracerProvider(fm.Tp)); err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to delete hint %s of challenge %s, got error: %s", hint.ID.ValueString(), chall.ID.ValueString(), err),
+				)
+				return
+			}
+		}
+	}
+
+	for i, hint := range chall.Hints {
+		// Requirements refer to previous hints, so they are already created
+		preqs := make([]int, 0, len(hint.Requirements))
+		for _, idx := range hint.Requirements {
+			if idx.ValueInt64() < 0 || idx.ValueInt64() >= int64(i) {
+				diags.AddAttributeError(
+					path.Root("hints").AtListIndex(i).AtName("requirements"),
+					"Invalid Hint Requirement",
+					fmt.Sprintf("Hint requirements must refer to previous hints, got index %d for hint %d.", idx.ValueInt64(), i),
+				)
+				return
+			}
+			preqs = append(preqs, utils.Atoi(chall.Hints[idx.ValueInt64()].ID.ValueString()))
+		}
+
+		if hint.ID.IsUnknown() || hint.ID.IsNull() {
+			res, _, err := fm.Client.PostHints(ctx, &ctfd.PostHintsParams{
+				Challenge: utils.Atoi(chall.ID.ValueString()),
+				Content:   hint.Content.ValueString(),
+				Cost:      int(hint.Cost.ValueInt64()),
+				Requirements: ctfd.Requirements{
+					Prerequisites: preqs,
+				},
+			}, WithTracerProvider(fm.Tp))
+			if err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to create hint of challenge %s, got error: %s", chall.ID.ValueString(), err),
+				)
+				return
+			}
+			chall.Hints[i].ID = types.StringValue(strconv.Itoa(res.ID))
+			continue
+		}
+
+		if slices.ContainsFunc(prior, func(h ChallengeHintModel) bool {
+			return h.ID.Equal(hint.ID) && h.Content.Equal(hint.Content) && h.Cost.Equal(hint.Cost) && slices.Equal(h.Requirements, hint.Requirements)
+		}) {
+			continue
+		}
+		if _, _, err := fm.Client.PatchHint(ctx, hint.ID.ValueString(), &ctfd.PatchHintsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
+			Content:   hint.Content.ValueString(),
+			Cost:      int(hint.Cost.ValueInt64()),
+			Requirements: ctfd.Requirements{
+				Prerequisites: preqs,
+			},
+		}, WithTracerProvider(fm.Tp)); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to update hint %s of challenge %s, got error: %s", hint.ID.ValueString(), chall.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+}
+
+// validateHintRequirements checks the configured hint requirements refer to previous
+// hints, as the hints are created in order.
+func validateHintRequirements(hints types.List, diags *diag.Diagnostics) {
+	for i, elem := range hints.Elements() {
+		hint, ok := elem.(types.Object)
+		if !ok || hint.IsNull() || hint.IsUnknown() {
+			continue
+		}
+		reqs, ok := hint.Attributes()["requirements"].(types.List)
+		if !ok || reqs.IsNull() || reqs.IsUnknown() {
+			continue
+		}
+		for _, elem := range reqs.Elements() {
+			idx, ok := elem.(types.Int64)
+			if !ok || idx.IsNull() || idx.IsUnknown() {
+				continue
+			}
+			if idx.ValueInt64() < 0 || idx.ValueInt64() >= int64(i) {
+				diags.AddAttributeError(
+					path.Root("hints").AtListIndex(i).AtName("requirements"),
+					"Invalid Hint Requirement",
+					fmt.Sprintf("Hint requirements must refer to previous hints, got index %d for hint %d.", idx.ValueInt64(), i),
+				)
+			}
+		}
+	}
+}
+
+func (chall *ChallengeDynamicIaCModel) readHints(ctx context.Context, client *Client, diags *diag.Diagnostics, opts ...Option) {
+	resHints, _, err := client.GetChallengeHints(ctx, chall.ID.ValueString(), opts...)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read challenge %s hints, got error: %s", chall.ID.ValueString(), err),
+		)
+		return
+	}
+	if chall.Hints == nil && len(resHints) == 0 {
+		return
+	}
+
+	hints := make([]ChallengeHintModel, 0, len(resHints))
+	preqs := make(map[string][]int, len(resHints))
+	for _, hint := range resHints {
+		id := strconv.Itoa(hint.ID)
+		hints = append(hints, ChallengeHintModel{
+			ID:      types.StringValue(id),
+			Content: types.StringPointerValue(hint.Content),
+			Cost:    types.Int64Value(int64(hint.Cost)),
+		})
+		if hint.Requirements != nil {
+			preqs[id] = hint.Requirements.Prerequisites
+		}
+	}
+	hints = orderAsPrior(hints, chall.Hints, func(h ChallengeHintModel) types.String { return h.ID }, func(h ChallengeHintModel) types.String { return h.ID })
+
+	// Then turn the requirements identifiers back to indexes
+	for i, hint := range hints {
+		for _, preq := range preqs[hint.ID.ValueString()] {
+			idx := slices.IndexFunc(hints, func(h ChallengeHintModel) bool { return h.ID.ValueString() == strconv.Itoa(preq) })
+			if idx == -1 {
+				continue
+			}
+			hints[i].Requirements = append(hints[i].Requirements, types.Int64Value(int64(idx)))
+		}
+	}
+	chall.Hints = hints
+}
+
+// region files
+
+func (chall *ChallengeDynamicIaCModel) reconcileFiles(ctx context.Context, fm *Framework, prior []ChallengeFileModel, diags *diag.Diagnostics) {
+	// Do not go further if a previous step failed
+	if diags.HasError() {
+		return
+	}
+
+	// Files could not be updated, so changed ones are deleted then uploaded again
+	for _, file := range prior {
+		if !slices.ContainsFunc(chall.Files, func(f ChallengeFileModel) bool { return f.ID.Equal(file.ID) }) {
+			if _, err := fm.Client.DeleteFile(ctx, file.ID.ValueString(), WithTracerProvider(fm.Tp)); err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to delete file %s of challenge %s, got error: %s", file.ID.ValueString(), chall.ID.ValueString(), err),
+				)
+				return
+			}
+		}
+	}
+
+	for i, file := range chall.Files {
+		if !file.ID.IsUnknown() && !file.ID.IsNull() {
+			continue
+		}
+
+		content, err := fileContent(file.Path, file.ContentBase64)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("files").AtListIndex(i),
+				"Invalid File",
+				fmt.Sprintf("Unable to get content of file %s, got error: %s", file.Name.ValueString(), err),
+			)
+			return
+		}
+		res, _, err := fm.Client.PostFiles(ctx, &ctfd.PostFilesParams{
+			Files: []*ctfd.InputFile{
+				{
+					Name:    file.Name.ValueString(),
+					Content: content,
+				},
+			},
+			Challenge: utils.Ptr(utils.Atoi(chall.ID.ValueString())),
+		}, WithTracerProvider(fm.Tp))
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to upload file %s of challenge %s, got error: %s", file.Name.ValueString(), chall.ID.ValueString(), err),
+			)
+			return
+		}
+		chall.Files[i].ID = types.StringValue(strconv.Itoa(res[0].ID))
+		chall.Files[i].Location = types.StringValue(res[0].Location)
+		chall.Files[i].SHA256 = types.StringValue(sha256Sum(content))
+	}
+}
+
+func (chall *ChallengeDynamicIaCModel) readFiles(ctx context.Context, client *Client, diags *diag.Diagnostics, opts ...Option) {
+	resFiles, _, err := client.GetChallengeFiles(ctx, chall.ID.ValueString(), opts...)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read challenge %s files, got error: %s", chall.ID.ValueString(), err),
+		)
+		return
+	}
+	if chall.Files == nil && len(resFiles) == 0 {
+		return
+	}
+
+	// The content is not read back, so what was known of it is kept
+	files := make([]ChallengeFileModel, 0, len(resFiles))
+	for _, file := range resFiles {
+		id := types.StringValue(strconv.Itoa(file.ID))
+		f := ChallengeFileModel{
+			ID:            id,
+			Name:          types.StringValue(filepath.Base(file.Location)),
+			Path:          types.StringNull(),
+			ContentBase64: types.StringNull(),
+			SHA256:        types.StringNull(),
+			Location:      types.StringValue(file.Location),
+		}
+		if idx := slices.IndexFunc(chall.Files, func(f ChallengeFileModel) bool { return f.ID.Equal(id) }); idx != -1 {
+			f.Name = chall.Files[idx].Name
+			f.Path = chall.Files[idx].Path
+			f.ContentBase64 = chall.Files[idx].ContentBase64
+			f.SHA256 = chall.Files[idx].SHA256
+		}
+		files = append(files, f)
+	}
+	chall.Files = orderAsPrior(files, chall.Files, func(f ChallengeFileModel) types.String { return f.ID }, func(f ChallengeFileModel) types.String { return f.ID })
+}
+
+// fileContent returns the content of a file, either read from its path or decoded.
+func fileContent(pth, contentB64 types.String) ([]byte, error) {
+	switch {
+	case !pth.IsNull() && !contentB64.IsNull():
+		return nil, fmt.Errorf("only one of path or content_base64 could be set")
+	case !pth.IsNull():
+		return os.ReadFile(pth.ValueString())
+	case !contentB64.IsNull():
+		return base64.StdEncoding.DecodeString(contentB64.ValueString())
+	default:
+		return nil, fmt.Errorf("one of path or content_base64 must be set")
+	}
+}
+
+func sha256Sum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// fileSHA256 is a plan modifier computing the SHA256 sum of a file content,
+// such that a change of the file is planned.
+type fileSHA256 struct{}
+
+var _ planmodifier.String = (*fileSHA256)(nil)
+
+func (m fileSHA256) Description(ctx context.Context) string {
+	return "Computes the SHA256 sum of the file content."
+}
+
+func (m fileSHA256) MarkdownDescription(ctx context.Context) string {
+	return "Computes the SHA256 sum of the file content."
+}
+
+func (m fileSHA256) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	sum, known, diags := plannedFileSHA256(ctx, req.Config, req.Path.ParentPath())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+	resp.PlanValue = types.StringValue(sum)
+}
+
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target any) diag.Diagnostics
+}
+
+// plannedFileSHA256 computes the SHA256 sum of the file configured at the given path,
+// if its content is known.
+func plannedFileSHA256(ctx context.Context, config attributeGetter, file path.Path) (string, bool, diag.Diagnostics) {
+	var pth, contentB64 types.String
+	diags := config.GetAttribute(ctx, file.AtName("path"), &pth)
+	diags.Append(config.GetAttribute(ctx, file.AtName("content_base64"), &contentB64)...)
+	if diags.HasError() || pth.IsUnknown() || contentB64.IsUnknown() {
+		return "", false, diags
+	}
+
+	content, err := fileContent(pth, contentB64)
+	if err != nil {
+		diags.AddAttributeError(
+			file,
+			"Invalid File",
+			fmt.Sprintf("Unable to get file content, got error: %s", err),
+		)
+		return "", false, diags
+	}
+	return sha256Sum(content), true, diags
+}
+
+// orderAsPrior orders the values as the prior ones, matched by key, such that
+// lists read back do not produce diffs. Values not in prior are appended.
+func orderAsPrior[T, P any](values []T, prior []P, key func(T) types.String, priorKey func(P) types.String) []T {
+	out := make([]T, 0, len(values))
+	for _, p := range prior {
+		if idx := slices.IndexFunc(values, func(v T) bool { return key(v).Equal(priorKey(p)) }); idx != -1 {
+			out = append(out, values[idx])
+		}
+	}
+	for _, v := range values {
+		if !slices.ContainsFunc(prior, func(p P) bool { return key(v).Equal(priorKey(p)) }) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
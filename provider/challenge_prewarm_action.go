@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ action.Action              = (*challengePrewarmAction)(nil)
+	_ action.ActionWithConfigure = (*challengePrewarmAction)(nil)
+)
+
+const (
+	// defaultPrewarmTimeout is how long to wait for a pool to be pre-warmed by default.
+	defaultPrewarmTimeout = 10 * time.Minute
+
+	// prewarmPollInterval is how often the pool is checked while pre-warming.
+	prewarmPollInterval = 5 * time.Second
+)
+
+func NewChallengePrewarmAction() action.Action {
+	return &challengePrewarmAction{}
+}
+
+type challengePrewarmAction struct {
+	fm *Framework
+}
+
+type ChallengePrewarmActionModel struct {
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	Timeout     types.Int64  `tfsdk:"timeout"`
+}
+
+func (a *challengePrewarmAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_prewarm"
+}
+
+func (a *challengePrewarmAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Pre-warms the instances pool of a challenge, by triggering its refill then waiting until `min` instances are ready. This is useful after a scenario update, for the pool not to be cold until players show up.",
+		Attributes: map[string]schema.Attribute{
+			"challenge_id": schema.StringAttribute{
+				MarkdownDescription: "The challenge to pre-warm the pool of.",
+				Required:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "The timeout (in seconds) to wait for the pool to be ready. Defaults to 600.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *challengePrewarmAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	a.fm = fm
+}
+
+func (a *challengePrewarmAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, a.fm.Tp.Tracer(serviceName), a)
+	defer span.End()
+
+	var data ChallengePrewarmActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chall, _, err := a.fm.Client.GetChallenge(ctx, data.ChallengeID.ValueString(), WithTracerProvider(a.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read challenge %s, got error: %s", data.ChallengeID.ValueString(), err),
+		)
+		return
+	}
+	if chall.Min == 0 {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Challenge %s has no pool to pre-warm", data.ChallengeID.ValueString()),
+		})
+		return
+	}
+
+	timeout := defaultPrewarmTimeout
+	if !data.Timeout.IsNull() {
+		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	}
+	if err := prewarmPool(ctx, a.fm, data.ChallengeID.ValueString(), chall.Min, timeout, func(msg string) {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: msg,
+		})
+	}); err != nil {
+		resp.Diagnostics.AddError(
+			"Pre-warm Error",
+			fmt.Sprintf("Unable to pre-warm pool of challenge %s, got error: %s", data.ChallengeID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// prewarmPool triggers the refill of the instances pool of a challenge, then waits
+// until target instances are ready, or the timeout is reached.
+func prewarmPool(ctx context.Context, fm *Framework, challengeID string, target int, timeout time.Duration, progress func(string)) error {
+	if _, _, err := fm.Client.PostAdminPool(ctx, &ctfdcm.PostAdminPoolParams{
+		ChallengeID: challengeID,
+	}, WithTracerProvider(fm.Tp)); err != nil {
+		return fmt.Errorf("triggering pool refill: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(prewarmPollInterval)
+	defer ticker.Stop()
+	for {
+		pool, _, err := fm.Client.GetAdminPool(ctx, &ctfdcm.GetAdminPoolParams{
+			ChallengeID: challengeID,
+		}, WithTracerProvider(fm.Tp))
+		if err != nil {
+			return fmt.Errorf("getting pool: %w", err)
+		}
+		progress(fmt.Sprintf("Pool of challenge %s has %d/%d instances ready", challengeID, pool.Ready, target))
+		if pool.Ready >= target {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("pool not ready after %s, %d/%d instances ready", timeout, pool.Ready, target)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_ChallengePrewarmAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "pooled" {
+	name        = "Some pooled challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "visible"
+
+	scenario = var.scenario
+	min      = 1
+	max      = 2
+
+	lifecycle {
+		action_trigger {
+			events  = [after_create]
+			actions = [action.ctfdcm_challenge_prewarm.pooled]
+		}
+	}
+}
+
+action "ctfdcm_challenge_prewarm" "pooled" {
+	config {
+		challenge_id = ctfdcm_challenge_dynamiciac.pooled.id
+	}
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfd "github.com/ctfer-io/go-ctfd/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+// requirementsParams converts the requirements to their API counterpart.
+// Prerequisites must be challenge identifiers, else it is reported on their path.
+func requirementsParams(reqs *tfctfd.RequirementsSubresourceModel, diags *diag.Diagnostics) *ctfd.Requirements {
+	if reqs == nil {
+		return nil
+	}
+	preqs := make([]int, 0, len(reqs.Prerequisites))
+	for _, preq := range reqs.Prerequisites {
+		if id, ok := prerequisiteID(preq, diags); ok {
+			preqs = append(preqs, id)
+		}
+	}
+	return &ctfd.Requirements{
+		Anonymize:     tfctfd.FromBehavior(reqs.Behavior),
+		Prerequisites: preqs,
+	}
+}
+
+// prerequisiteID returns the challenge identifier of a prerequisite, or reports it on its path.
+func prerequisiteID(preq types.String, diags *diag.Diagnostics) (int, bool) {
+	id, err := strconv.Atoi(preq.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("requirements").AtName("prerequisites").AtSetValue(preq),
+			"Invalid Prerequisite",
+			fmt.Sprintf("Prerequisites must be challenge identifiers, got %q.", preq.ValueString()),
+		)
+		return 0, false
+	}
+	return id, true
+}
+
+// validatePrerequisites checks the known prerequisites are challenge identifiers.
+func validatePrerequisites(preqs types.Set, diags *diag.Diagnostics) {
+	for _, elem := range preqs.Elements() {
+		preq, ok := elem.(types.String)
+		if !ok || preq.IsNull() || preq.IsUnknown() {
+			continue
+		}
+		_, _ = prerequisiteID(preq, diags)
+	}
+}
+
+var providerRequirementsAttributes = map[string]schema.Attribute{
+	"requirements_check": schema.BoolAttribute{
+		MarkdownDescription: "Whether to check, during plan, that the `requirements` prerequisites and `next` challenge of the `ctfdcm_challenge_dynamiciac` exist and do not form a cycle. The existing challenges are fetched once per run to do so.",
+		Optional:            true,
+	},
+}
+
+// challengeGraph holds the prerequisites and next challenges of all the existing
+// challenges, loaded once per provider run as every challenge plan needs it.
+// Challenges created afterwards, e.g. the prerequisites of a challenge created during
+// the same apply, are fetched when first needed. The planned challenges replace the
+// existing ones, such that the plans checked afterwards consider their changes.
+type challengeGraph struct {
+	once    sync.Once
+	err     error
+	mu      sync.Mutex
+	nodes   map[int]challengeNode
+	planned map[int]challengeNode
+	missing map[int]struct{}
+}
+
+type challengeNode struct {
+	Name          string
+	Prerequisites []int
+	Next          *int
+}
+
+// load fetches the challenges requirements and next, if not already.
+func (cg *challengeGraph) load(ctx context.Context, fm *Framework) error {
+	cg.once.Do(func() {
+		challs, _, err := fm.Client.GetChallenges(ctx, &ctfd.GetChallengesParams{}, WithTracerProvider(fm.Tp))
+		if err != nil {
+			cg.err = fmt.Errorf("getting challenges: %w", err)
+			return
+		}
+		nodes := make(map[int]challengeNode, len(challs))
+		for _, c := range challs {
+			node, err := fetchChallengeNode(ctx, fm, c.ID)
+			if err != nil {
+				cg.err = err
+				return
+			}
+			nodes[c.ID] = node
+		}
+
+		cg.mu.Lock()
+		defer cg.mu.Unlock()
+		cg.nodes = nodes
+		cg.missing = map[int]struct{}{}
+	})
+	return cg.err
+}
+
+// plan replaces a challenge of the graph by its planned counterpart.
+func (cg *challengeGraph) plan(id int, planned challengeNode) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	if cg.planned == nil {
+		cg.planned = map[int]challengeNode{}
+	}
+	cg.planned[id] = planned
+}
+
+// node reports whether a challenge of the graph exists, fetching it if it was created
+// after the graph has been loaded. As the existing challenges have all been listed
+// then, one that could not be fetched is considered missing.
+func (cg *challengeGraph) node(ctx context.Context, fm *Framework, id int) bool {
+	cg.mu.Lock()
+	_, ok := cg.nodes[id]
+	_, missing := cg.missing[id]
+	cg.mu.Unlock()
+	if ok || missing {
+		return ok
+	}
+
+	node, err := fetchChallengeNode(ctx, fm, id)
+
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	if err != nil {
+		logDebug(ctx, "Challenge could not be fetched, considering it missing", map[string]any{
+			"id":    id,
+			"error": err.Error(),
+		})
+		cg.missing[id] = struct{}{}
+		return false
+	}
+	cg.nodes[id] = node
+	return true
+}
+
+// fetchChallengeNode fetches the name, requirements and next of a challenge.
+func fetchChallengeNode(ctx context.Context, fm *Framework, id int) (challengeNode, error) {
+	sid := strconv.Itoa(id)
+	chall, _, err := fm.Client.GetChallenge(ctx, sid, WithTracerProvider(fm.Tp))
+	if err != nil {
+		return challengeNode{}, fmt.Errorf("getting challenge %s: %w", sid, err)
+	}
+	reqs, _, err := fm.Client.GetChallengeRequirements(ctx, sid, WithTracerProvider(fm.Tp))
+	if err != nil {
+		return challengeNode{}, fmt.Errorf("getting challenge %s requirements: %w", sid, err)
+	}
+	node := challengeNode{
+		Name: chall.Name,
+		Next: chall.NextID,
+	}
+	if reqs != nil {
+		node.Prerequisites = reqs.Prerequisites
+	}
+	return node, nil
+}
+
+// path returns the challenges to go through from one challenge to another, following
+// the order players solve them in (a prerequisite then the challenge, a challenge then
+// its next one), or nil if the latter could not be reached.
+func (cg *challengeGraph) path(from, to int) []int {
+	cg.mu.Lock()
+	nodes := make(map[int]challengeNode, len(cg.nodes)+len(cg.planned))
+	maps.Copy(nodes, cg.nodes)
+	maps.Copy(nodes, cg.planned)
+	cg.mu.Unlock()
+
+	after := map[int][]int{}
+	for n, c := range nodes {
+		for _, preq := range c.Prerequisites {
+			after[preq] = append(after[preq], n)
+		}
+		if c.Next != nil {
+			after[n] = append(after[n], *c.Next)
+		}
+	}
+
+	// Breadth-first search, such that the shortest path is reported
+	parents := map[int]int{from: from}
+	queue := []int{from}
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == to {
+			p := []int{cur}
+			for cur != from {
+				cur = parents[cur]
+				p = append([]int{cur}, p...)
+			}
+			return p
+		}
+		slices.Sort(after[cur])
+		for _, n := range after[cur] {
+			if _, ok := parents[n]; !ok {
+				parents[n] = cur
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil
+}
+
+// names formats the challenges of a path by their name.
+func (cg *challengeGraph) names(p []int) string {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	names := make([]string, 0, len(p))
+	for _, n := range p {
+		node, ok := cg.planned[n]
+		if !ok {
+			node = cg.nodes[n]
+		}
+		names = append(names, fmt.Sprintf("%s (%d)", node.Name, n))
+	}
+	return strings.Join(names, " -> ")
+}
+
+// checkRequirements validates the prerequisites and next challenge of the planned
+// challenge: they must exist, and must not form a cycle, else players could never
+// solve them all. Only known identifiers are checked, and the other challenges are
+// considered as planned if they were so far, else as they exist.
+func (r *challengeDynamicIaCResource) checkRequirements(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	diags := &resp.Diagnostics
+	var id, name types.String
+	var next types.Int64
+	var reqs *tfctfd.RequirementsSubresourceModel
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &id)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("next"), &next)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("requirements"), &reqs)...)
+	if diags.HasError() {
+		return
+	}
+
+	planned := challengeNode{
+		Name: name.ValueString(),
+	}
+	preqs := map[int]types.String{}
+	if reqs != nil {
+		for _, preq := range reqs.Prerequisites {
+			if preq.IsUnknown() {
+				continue
+			}
+			// Invalid identifiers are reported when validating the configuration
+			pid, err := strconv.Atoi(preq.ValueString())
+			if err != nil {
+				continue
+			}
+			preqs[pid] = preq
+			planned.Prerequisites = append(planned.Prerequisites, pid)
+		}
+	}
+	if !next.IsNull() && !next.IsUnknown() {
+		planned.Next = utils.Ptr(int(next.ValueInt64()))
+	}
+	slices.Sort(planned.Prerequisites)
+
+	// Challenges not created yet have no identifier, and could not be part of a cycle
+	cid := 0
+	if !id.IsUnknown() {
+		cid, _ = strconv.Atoi(id.ValueString())
+		r.fm.challengeGraph.plan(cid, planned)
+	}
+	if len(planned.Prerequisites) == 0 && planned.Next == nil {
+		return
+	}
+
+	if err := r.fm.challengeGraph.load(ctx, r.fm); err != nil {
+		diags.AddWarning(
+			"Requirements Check Skipped",
+			fmt.Sprintf("Unable to check the requirements, got error: %s", err),
+		)
+		return
+	}
+
+	for _, pid := range planned.Prerequisites {
+		pth := path.Root("requirements").AtName("prerequisites").AtSetValue(preqs[pid])
+		if !r.fm.challengeGraph.node(ctx, r.fm, pid) {
+			diags.AddAttributeError(
+				pth,
+				"Missing Prerequisite",
+				fmt.Sprintf("Challenge %d does not exist.", pid),
+			)
+			continue
+		}
+		if cid == 0 {
+			continue
+		}
+		if p := r.fm.challengeGraph.path(cid, pid); p != nil {
+			diags.AddAttributeError(
+				pth,
+				"Requirements Cycle",
+				fmt.Sprintf("Challenge %d is required while it could only be solved after this one: %s.", pid, r.fm.challengeGraph.names(p)),
+			)
+		}
+	}
+	if planned.Next != nil {
+		if !r.fm.challengeGraph.node(ctx, r.fm, *planned.Next) {
+			diags.AddAttributeError(
+				path.Root("next"),
+				"Missing Next Challenge",
+				fmt.Sprintf("Challenge %d does not exist.", *planned.Next),
+			)
+			return
+		}
+		if cid == 0 {
+			return
+		}
+		if p := r.fm.challengeGraph.path(*planned.Next, cid); p != nil {
+			diags.AddAttributeError(
+				path.Root("next"),
+				"Requirements Cycle",
+				fmt.Sprintf("Challenge %d is suggested next while it could only be solved before this one: %s.", *planned.Next, r.fm.challengeGraph.names(p)),
+			)
+		}
+	}
+}
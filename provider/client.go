@@ -16,20 +16,45 @@ import (
 	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
 	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var apiTransport = ctfd.WithTransport(otelhttp.NewTransport(http.DefaultTransport))
+var apiTransport = ctfd.WithTransport(otelhttp.NewTransport(&metricsTransport{next: http.DefaultTransport}))
 
 func apiOptions(ctx context.Context) []ctfd.Option {
 	return []ctfd.Option{
-		ctfd.WithContext(ctx),
+		ctfd.WithContext(withAPICall(ctx)),
 		apiTransport,
 	}
 }
 
+// Attributes set on the API spans, such that a failing challenge or instance could
+// be found from its traces.
+const (
+	attrChallengeID = attribute.Key("ctfd.challenge.id")
+	attrSourceID    = attribute.Key("ctfd.source.id")
+	attrFlagID      = attribute.Key("ctfd.flag.id")
+	attrHintID      = attribute.Key("ctfd.hint.id")
+	attrFileID      = attribute.Key("ctfd.file.id")
+	attrTagID       = attribute.Key("ctfd.tag.id")
+	attrTopicID     = attribute.Key("ctfd.topic.id")
+	attrTokenID     = attribute.Key("ctfd.token.id")
+)
+
+// endAPISpan records the error of the API call, if any, then ends its span.
+func endAPISpan(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
 func GetNonceAndSession(ctx context.Context, url string, opts ...Option) (nonce, session string, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
 
 	return ctfd.GetNonceAndSession(url, apiOptions(ctx)...)
 }
@@ -44,122 +69,365 @@ func NewClient(url, nonce, session, apiKey string) *Client {
 	}
 }
 
-func (cli *Client) Login(ctx context.Context, params *ctfd.LoginParams, opts ...Option) error {
+func (cli *Client) Login(ctx context.Context, params *ctfd.LoginParams, opts ...Option) (err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
 
 	return cli.sub.Login(params, apiOptions(ctx)...)
 }
 
 // region challenges
 
-func (cli *Client) GetChallenges(ctx context.Context, params *ctfd.GetChallengesParams, opts ...Option) ([]*ctfd.Challenge, *ctfd.MetaResponse, error) {
+func (cli *Client) GetChallenges(ctx context.Context, params *ctfd.GetChallengesParams, opts ...Option) (_ []*ctfd.Challenge, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
 
 	return cli.sub.GetChallenges(params, apiOptions(ctx)...)
 }
 
-func (cli *Client) GetChallenge(ctx context.Context, id string, opts ...Option) (*ctfdcm.Challenge, *ctfd.MetaResponse, error) {
+func (cli *Client) GetChallenge(ctx context.Context, id string, opts ...Option) (_ *ctfdcm.Challenge, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
 
 	return ctfdcm.GetChallenge(cli.sub, id, apiOptions(ctx)...)
 }
 
-func (cli *Client) PostChallenges(ctx context.Context, params *ctfdcm.PostChallengesParams, opts ...Option) (*ctfdcm.Challenge, *ctfd.MetaResponse, error) {
+func (cli *Client) PostChallenges(ctx context.Context, params *ctfdcm.PostChallengesParams, opts ...Option) (res *ctfdcm.Challenge, meta *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
 
-	return ctfdcm.PostChallenges(cli.sub, params, apiOptions(ctx)...)
+	res, meta, err = ctfdcm.PostChallenges(cli.sub, params, apiOptions(ctx)...)
+	if err == nil && res != nil {
+		span.SetAttributes(attrChallengeID.Int(res.ID))
+	}
+	return res, meta, err
 }
 
-func (cli *Client) PatchChallenges(ctx context.Context, id string, params *ctfdcm.PatchChallengeParams, opts ...Option) (*ctfdcm.Challenge, *ctfd.MetaResponse, error) {
+func (cli *Client) PatchChallenges(ctx context.Context, id string, params *ctfdcm.PatchChallengeParams, opts ...Option) (_ *ctfdcm.Challenge, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
 
 	return ctfdcm.PatchChallenges(cli.sub, id, params, apiOptions(ctx)...)
 }
 
-func (cli *Client) DeleteChallenge(ctx context.Context, id string, opts ...Option) (*ctfd.MetaResponse, error) {
+func (cli *Client) DeleteChallenge(ctx context.Context, id string, opts ...Option) (_ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
 
 	return cli.sub.DeleteChallenge(utils.Atoi(id), apiOptions(ctx)...)
 }
 
-func (cli *Client) GetChallengeTags(ctx context.Context, id string, opts ...Option) ([]*ctfd.Tag, *ctfd.MetaResponse, error) {
+func (cli *Client) GetChallengeTags(ctx context.Context, id string, opts ...Option) (_ []*ctfd.Tag, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
 
 	return cli.sub.GetChallengeTags(utils.Atoi(id), apiOptions(ctx)...)
 }
 
-func (cli *Client) GetChallengeTopics(ctx context.Context, id string, opts ...Option) ([]*ctfd.Topic, *ctfd.MetaResponse, error) {
+func (cli *Client) GetChallengeTopics(ctx context.Context, id string, opts ...Option) (_ []*ctfd.Topic, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
 
 	return cli.sub.GetChallengeTopics(utils.Atoi(id), apiOptions(ctx)...)
 }
 
-func (cli *Client) GetChallengeRequirements(ctx context.Context, id string, opts ...Option) (*ctfd.Requirements, *ctfd.MetaResponse, error) {
+func (cli *Client) GetChallengeRequirements(ctx context.Context, id string, opts ...Option) (_ *ctfd.Requirements, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
 
 	return cli.sub.GetChallengeRequirements(utils.Atoi(id), apiOptions(ctx)...)
 }
 
+// region flags
+
+func (cli *Client) GetChallengeFlags(ctx context.Context, id string, opts ...Option) (_ []*ctfd.Flag, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
+
+	return cli.sub.GetChallengeFlags(utils.Atoi(id), apiOptions(ctx)...)
+}
+
+func (cli *Client) PostFlags(ctx context.Context, params *ctfd.PostFlagsParams, opts ...Option) (res *ctfd.Flag, meta *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.Int(params.Challenge))
+
+	res, meta, err = cli.sub.PostFlags(params, apiOptions(ctx)...)
+	if err == nil && res != nil {
+		span.SetAttributes(attrFlagID.Int(res.ID))
+	}
+	return res, meta, err
+}
+
+func (cli *Client) PatchFlag(ctx context.Context, id string, params *ctfd.PatchFlagParams, opts ...Option) (_ *ctfd.Flag, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrFlagID.String(id))
+
+	return cli.sub.PatchFlag(id, params, apiOptions(ctx)...)
+}
+
+func (cli *Client) DeleteFlag(ctx context.Context, id string, opts ...Option) (_ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrFlagID.String(id))
+
+	return cli.sub.DeleteFlag(id, apiOptions(ctx)...)
+}
+
+// region hints
+
+func (cli *Client) GetChallengeHints(ctx context.Context, id string, opts ...Option) (_ []*ctfd.Hint, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
+
+	return cli.sub.GetChallengeHints(utils.Atoi(id), apiOptions(ctx)...)
+}
+
+func (cli *Client) PostHints(ctx context.Context, params *ctfd.PostHintsParams, opts ...Option) (res *ctfd.Hint, meta *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.Int(params.Challenge))
+
+	res, meta, err = cli.sub.PostHints(params, apiOptions(ctx)...)
+	if err == nil && res != nil {
+		span.SetAttributes(attrHintID.Int(res.ID))
+	}
+	return res, meta, err
+}
+
+func (cli *Client) PatchHint(ctx context.Context, id string, params *ctfd.PatchHintsParams, opts ...Option) (_ *ctfd.Hint, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrHintID.String(id))
+
+	return cli.sub.PatchHint(id, params, apiOptions(ctx)...)
+}
+
+func (cli *Client) DeleteHint(ctx context.Context, id string, opts ...Option) (_ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrHintID.String(id))
+
+	return cli.sub.DeleteHint(id, apiOptions(ctx)...)
+}
+
+// region files
+
+func (cli *Client) GetChallengeFiles(ctx context.Context, id string, opts ...Option) (_ []*ctfd.File, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(id))
+
+	return cli.sub.GetChallengeFiles(utils.Atoi(id), apiOptions(ctx)...)
+}
+
+func (cli *Client) PostFiles(ctx context.Context, params *ctfd.PostFilesParams, opts ...Option) (_ []*ctfd.File, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	if params.Challenge != nil {
+		span.SetAttributes(attrChallengeID.Int(*params.Challenge))
+	}
+
+	return cli.sub.PostFiles(params, apiOptions(ctx)...)
+}
+
+func (cli *Client) GetFileContent(ctx context.Context, file *ctfd.File, opts ...Option) (_ []byte, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrFileID.Int(file.ID))
+
+	return cli.sub.GetFileContent(file, apiOptions(ctx)...)
+}
+
+func (cli *Client) DeleteFile(ctx context.Context, id string, opts ...Option) (_ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrFileID.String(id))
+
+	return cli.sub.DeleteFile(id, apiOptions(ctx)...)
+}
+
 // region instances
 
-func (cli *Client) GetAdminInstance(ctx context.Context, params *ctfdcm.GetAdminInstanceParams, opts ...Option) (*ctfdcm.Instance, *ctfd.MetaResponse, error) {
+func (cli *Client) GetAdminInstances(ctx context.Context, params *ctfdcm.GetAdminInstancesParams, opts ...Option) (_ []*ctfdcm.Instance, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	if params.ChallengeID != nil {
+		span.SetAttributes(attrChallengeID.String(*params.ChallengeID))
+	}
+	if params.SourceID != nil {
+		span.SetAttributes(attrSourceID.String(*params.SourceID))
+	}
+
+	return ctfdcm.GetAdminInstances(cli.sub, params, apiOptions(ctx)...)
+}
+
+func (cli *Client) GetAdminInstance(ctx context.Context, params *ctfdcm.GetAdminInstanceParams, opts ...Option) (_ *ctfdcm.Instance, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(
+		attrChallengeID.String(params.ChallengeID),
+		attrSourceID.String(params.SourceID),
+	)
 
 	return ctfdcm.GetAdminInstance(cli.sub, params, apiOptions(ctx)...)
 }
 
-func (cli *Client) PostAdminInstance(ctx context.Context, params *ctfdcm.PostAdminInstanceParams, opts ...Option) (*ctfdcm.Instance, *ctfd.MetaResponse, error) {
+func (cli *Client) PostAdminInstance(ctx context.Context, params *ctfdcm.PostAdminInstanceParams, opts ...Option) (_ *ctfdcm.Instance, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(
+		attrChallengeID.String(params.ChallengeID),
+		attrSourceID.String(params.SourceID),
+	)
 
 	return ctfdcm.PostAdminInstance(cli.sub, params, apiOptions(ctx)...)
 }
 
-func (cli *Client) DeleteAdminInstance(ctx context.Context, params *ctfdcm.DeleteAdminInstanceParams, opts ...Option) (*ctfdcm.Instance, *ctfd.MetaResponse, error) {
+func (cli *Client) PatchAdminInstance(ctx context.Context, params *ctfdcm.PatchAdminInstanceParams, opts ...Option) (_ *ctfdcm.Instance, _ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(
+		attrChallengeID.String(params.ChallengeID),
+		attrSourceID.String(params.SourceID),
+	)
+
+	return ctfdcm.PatchAdminInstance(cli.sub, params, apiOptions(ctx)...)
+}
+
+func (cli *Client) DeleteAdminInstance(ctx context.Context, params *ctfdcm.DeleteAdminInstanceParams, opts ...Option) (_ *ctfdcm.Instance, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(
+		attrChallengeID.String(params.ChallengeID),
+		attrSourceID.String(params.SourceID),
+	)
 
 	return ctfdcm.DeleteAdminInstance(cli.sub, params, apiOptions(ctx)...)
 }
 
+// region pools
+
+func (cli *Client) GetAdminPool(ctx context.Context, params *ctfdcm.GetAdminPoolParams, opts ...Option) (_ *ctfdcm.Pool, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(params.ChallengeID))
+
+	return ctfdcm.GetAdminPool(cli.sub, params, apiOptions(ctx)...)
+}
+
+func (cli *Client) PostAdminPool(ctx context.Context, params *ctfdcm.PostAdminPoolParams, opts ...Option) (_ *ctfdcm.Pool, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.String(params.ChallengeID))
+
+	return ctfdcm.PostAdminPool(cli.sub, params, apiOptions(ctx)...)
+}
+
+// region mana
+
+func (cli *Client) GetAdminMana(ctx context.Context, params *ctfdcm.GetAdminManaParams, opts ...Option) (_ *ctfdcm.Mana, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrSourceID.String(params.SourceID))
+
+	return ctfdcm.GetAdminMana(cli.sub, params, apiOptions(ctx)...)
+}
+
+func (cli *Client) PatchAdminMana(ctx context.Context, params *ctfdcm.PatchAdminManaParams, opts ...Option) (_ *ctfdcm.Mana, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrSourceID.String(params.SourceID))
+
+	return ctfdcm.PatchAdminMana(cli.sub, params, apiOptions(ctx)...)
+}
+
+// region settings
+
+func (cli *Client) GetAdminSettings(ctx context.Context, opts ...Option) (_ *ctfdcm.Settings, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+
+	return ctfdcm.GetAdminSettings(cli.sub, apiOptions(ctx)...)
+}
+
+func (cli *Client) PatchAdminSettings(ctx context.Context, params *ctfdcm.PatchAdminSettingsParams, opts ...Option) (_ *ctfdcm.Settings, _ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+
+	return ctfdcm.PatchAdminSettings(cli.sub, params, apiOptions(ctx)...)
+}
+
+// region tokens
+
+func (cli *Client) PostTokens(ctx context.Context, params *ctfd.PostTokensParams, opts ...Option) (res *ctfd.Token, meta *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+
+	res, meta, err = cli.sub.PostTokens(params, apiOptions(ctx)...)
+	if err == nil && res != nil {
+		span.SetAttributes(attrTokenID.Int(res.ID))
+	}
+	return res, meta, err
+}
+
+func (cli *Client) DeleteToken(ctx context.Context, id string, opts ...Option) (_ *ctfd.MetaResponse, err error) {
+	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrTokenID.String(id))
+
+	return cli.sub.DeleteToken(id, apiOptions(ctx)...)
+}
+
 // region tags
 
-func (cli *Client) PostTags(ctx context.Context, params *ctfd.PostTagsParams, opts ...Option) (*ctfd.Tag, *ctfd.MetaResponse, error) {
+func (cli *Client) PostTags(ctx context.Context, params *ctfd.PostTagsParams, opts ...Option) (res *ctfd.Tag, meta *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.Int(params.Challenge))
 
-	return cli.sub.PostTags(params, apiOptions(ctx)...)
+	res, meta, err = cli.sub.PostTags(params, apiOptions(ctx)...)
+	if err == nil && res != nil {
+		span.SetAttributes(attrTagID.Int(res.ID))
+	}
+	return res, meta, err
 }
 
-func (cli *Client) DeleteTag(ctx context.Context, id string, opts ...Option) (*ctfd.MetaResponse, error) {
+func (cli *Client) DeleteTag(ctx context.Context, id string, opts ...Option) (_ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrTagID.String(id))
 
 	return cli.sub.DeleteTag(id, apiOptions(ctx)...)
 }
 
 // region topics
 
-func (cli *Client) PostTopics(ctx context.Context, params *ctfd.PostTopicsParams, opts ...Option) (*ctfd.Topic, *ctfd.MetaResponse, error) {
+func (cli *Client) PostTopics(ctx context.Context, params *ctfd.PostTopicsParams, opts ...Option) (res *ctfd.Topic, meta *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrChallengeID.Int(params.Challenge))
 
-	return cli.sub.PostTopics(params, apiOptions(ctx)...)
+	res, meta, err = cli.sub.PostTopics(params, apiOptions(ctx)...)
+	if err == nil && res != nil {
+		span.SetAttributes(attrTopicID.Int(res.ID))
+	}
+	return res, meta, err
 }
 
-func (cli *Client) DeleteTopic(ctx context.Context, params *ctfd.DeleteTopicArgs, opts ...Option) (*ctfd.MetaResponse, error) {
+func (cli *Client) DeleteTopic(ctx context.Context, params *ctfd.DeleteTopicArgs, opts ...Option) (_ *ctfd.MetaResponse, err error) {
 	ctx, span := tfctfd.StartAPISpan(ctx, getTracer(opts...))
-	defer span.End()
+	defer endAPISpan(span, &err)
+	span.SetAttributes(attrTopicID.String(params.ID))
 
 	return cli.sub.DeleteTopic(params, apiOptions(ctx)...)
 }
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+func Test_Client_APISpans(t *testing.T) {
+	// Instances could be read, but not created
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"success":true,"data":{}}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"success":false,"errors":{"sourceId":["Invalid source"]}}`))
+	}))
+	defer srv.Close()
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+	})
+
+	ctx := context.Background()
+	client := NewClient(srv.URL, "", "", "ctfd_0123456789")
+	if _, _, err := client.GetAdminInstance(ctx, &ctfdcm.GetAdminInstanceParams{
+		ChallengeID: "1",
+		SourceID:    "2",
+	}, WithTracerProvider(tp)); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if _, _, err := client.PostAdminInstance(ctx, &ctfdcm.PostAdminInstanceParams{
+		ChallengeID: "1",
+		SourceID:    "3",
+	}, WithTracerProvider(tp)); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var tests = map[string]struct {
+		SourceID string
+		Status   int
+		Code     codes.Code
+	}{
+		"succeeded": {
+			SourceID: "2",
+			Status:   http.StatusOK,
+			Code:     codes.Unset,
+		},
+		"failed": {
+			SourceID: "3",
+			Status:   http.StatusBadRequest,
+			Code:     codes.Error,
+		},
+	}
+
+	spans := rec.Ended()
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			// The API span is the one of the source, the HTTP ones being its children
+			var span sdktrace.ReadOnlySpan
+			for _, s := range spans {
+				for _, kv := range s.Attributes() {
+					if kv.Key == attrSourceID && kv.Value.AsString() == tt.SourceID {
+						span = s
+					}
+				}
+			}
+			if span == nil {
+				t.Fatalf("expected a span for source %s, got %d spans", tt.SourceID, len(spans))
+			}
+
+			attrs := attribute.NewSet(span.Attributes()...)
+			if v, ok := attrs.Value(attrChallengeID); !ok || v.AsString() != "1" {
+				t.Errorf("expected the challenge identifier to be set, got %v", v)
+			}
+			if v, ok := attrs.Value(semconv.HTTPResponseStatusCodeKey); !ok || v.AsInt64() != int64(tt.Status) {
+				t.Errorf("expected the HTTP status %d, got %v", tt.Status, v)
+			}
+			if span.Status().Code != tt.Code {
+				t.Errorf("expected the status %s, got %s", tt.Code, span.Status().Code)
+			}
+		})
+	}
+}
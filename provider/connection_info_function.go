@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*connectionInfoFunction)(nil)
+
+func NewConnectionInfoFunction() function.Function {
+	return &connectionInfoFunction{}
+}
+
+type connectionInfoFunction struct{}
+
+func (f *connectionInfoFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "connection_info"
+}
+
+func (f *connectionInfoFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Render a connection information template.",
+		MarkdownDescription: "Renders a connection information template with the identity of an instance, as Chall-Manager scenarios usually do. The template uses the Go `text/template` syntax, with the identity available as `{{ .Identity }}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "template",
+				MarkdownDescription: "The connection information template, e.g. `https://{{ .Identity }}.my-ctf.lan`.",
+			},
+			function.StringParameter{
+				Name:                "identity",
+				MarkdownDescription: "The identity of the instance.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *connectionInfoFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tmpl, identity string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &tmpl, &identity))
+	if resp.Error != nil {
+		return
+	}
+
+	t, err := template.New("connection_info").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid template: %s", err))
+		return
+	}
+	buf := &strings.Builder{}
+	if err := t.Execute(buf, map[string]string{
+		"Identity": identity,
+	}); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Rendering template: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, buf.String()))
+}
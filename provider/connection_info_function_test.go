@@ -0,0 +1,51 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_ConnectionInfoFunction(t *testing.T) {
+	var tests = map[string]struct {
+		Template  string
+		Identity  string
+		Expected  string
+		ExpectErr bool
+	}{
+		"http": {
+			Template: "https://{{ .Identity }}.my-ctf.lan",
+			Identity: "a0b1c2d3",
+			Expected: "https://a0b1c2d3.my-ctf.lan",
+		},
+		"static": {
+			Template: "nc my-ctf.lan 1337",
+			Identity: "a0b1c2d3",
+			Expected: "nc my-ctf.lan 1337",
+		},
+		"invalid-template": {
+			Template:  "https://{{ .Identity }.my-ctf.lan",
+			Identity:  "a0b1c2d3",
+			ExpectErr: true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			res, err := runFunction(t, provider.NewConnectionInfoFunction(), types.StringValue(tt.Template), types.StringValue(tt.Identity))
+			if tt.ExpectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := res.(types.String).ValueString(); got != tt.Expected {
+				t.Errorf("expected %s, got %s", tt.Expected, got)
+			}
+		})
+	}
+}
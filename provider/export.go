@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+
+	ctfd "github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+const (
+	// ExportFormatHCL exports the challenges as Terraform configuration, with import
+	// blocks to adopt the existing challenges.
+	ExportFormatHCL = "hcl"
+	// ExportFormatYAML exports the challenges as ctfcli challenge.yml bundles, one
+	// directory per challenge.
+	ExportFormatYAML = "yaml"
+
+	// exportHCLFile is the file the challenges are exported to in the HCL format.
+	exportHCLFile = "challenges.tf"
+)
+
+// exportedChallenge is a dynamic_iac challenge read from CTFd, along with the
+// content of its files.
+type exportedChallenge struct {
+	*ChallengeDynamicIaCModel
+
+	// label is unique among the exported challenges, and usable both as a Terraform
+	// resource name and as a directory name.
+	label string
+	files []exportedFile
+}
+
+type exportedFile struct {
+	name    string
+	content []byte
+}
+
+// Export logs into the CTFd configured by the same environment variables as the
+// provider, then writes all its dynamic_iac challenges to the output directory,
+// either in the HCL or the YAML format.
+func Export(ctx context.Context, format, output string, opts ...Option) error {
+	if format != ExportFormatHCL && format != ExportFormatYAML {
+		return fmt.Errorf("invalid format %q, expected %s or %s", format, ExportFormatHCL, ExportFormatYAML)
+	}
+
+	client, err := exportClient(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Names of all the challenges, as requirements could refer to other types
+	challs, _, err := client.GetChallenges(ctx, &ctfd.GetChallengesParams{}, opts...)
+	if err != nil {
+		return fmt.Errorf("getting challenges: %w", err)
+	}
+	names := make(map[int]string, len(challs))
+	for _, c := range challs {
+		names[c.ID] = c.Name
+	}
+
+	exported := []*exportedChallenge{}
+	labels := map[string]struct{}{}
+	for _, c := range challs {
+		if c.Type != "dynamic_iac" {
+			continue
+		}
+		ec, err := exportChallenge(ctx, client, c.ID, opts...)
+		if err != nil {
+			return err
+		}
+		ec.label = exportLabel(c.Name)
+		if _, ok := labels[ec.label]; ok {
+			ec.label = fmt.Sprintf("%s_%d", ec.label, c.ID)
+		}
+		labels[ec.label] = struct{}{}
+		exported = append(exported, ec)
+	}
+
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return err
+	}
+	if format == ExportFormatHCL {
+		return exportHCL(output, exported)
+	}
+	return exportYAML(output, exported, names)
+}
+
+// exportClient creates the CTFd API client from the environment variables, as
+// the provider does when not configured explicitly.
+func exportClient(ctx context.Context, opts ...Option) (*Client, error) {
+	url := os.Getenv("CTFD_URL")
+	apiKey := os.Getenv("CTFD_API_KEY")
+	username := os.Getenv("CTFD_ADMIN_USERNAME")
+	password := os.Getenv("CTFD_ADMIN_PASSWORD")
+
+	up := username != "" && password != ""
+	if url == "" || (apiKey == "" && !up) {
+		return nil, errors.New("CTFD_URL and either CTFD_API_KEY or CTFD_ADMIN_USERNAME and CTFD_ADMIN_PASSWORD must be set")
+	}
+
+	nonce, session, err := GetNonceAndSession(ctx, url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching nonce and session: %w", err)
+	}
+	client := NewClient(url, nonce, session, apiKey)
+	if up {
+		if err := client.Login(ctx, &ctfd.LoginParams{
+			Name:     username,
+			Password: password,
+		}, opts...); err != nil {
+			return nil, fmt.Errorf("logging in: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// exportChallenge reads a dynamic_iac challenge, and downloads its files.
+func exportChallenge(ctx context.Context, client *Client, id int, opts ...Option) (*exportedChallenge, error) {
+	chall := &ChallengeDynamicIaCModel{}
+	chall.ID = types.StringValue(strconv.Itoa(id))
+
+	diags := diag.Diagnostics{}
+	chall.Read(ctx, client, &diags, opts...)
+	if diags.HasError() {
+		d := diags.Errors()[0]
+		return nil, fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+
+	ec := &exportedChallenge{
+		ChallengeDynamicIaCModel: chall,
+	}
+	for _, file := range chall.Files {
+		content, err := client.GetFileContent(ctx, &ctfd.File{
+			ID:       utils.Atoi(file.ID.ValueString()),
+			Location: file.Location.ValueString(),
+		}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("getting challenge %d file %s content: %w", id, file.Name.ValueString(), err)
+		}
+		ec.files = append(ec.files, exportedFile{
+			name:    file.Name.ValueString(),
+			content: content,
+		})
+	}
+	return ec, nil
+}
+
+// exportLabel derives a Terraform resource name from a challenge name.
+func exportLabel(name string) string {
+	label := strings.Trim(nonRepositoryChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "challenge_" + label
+	}
+	return label
+}
+
+// writeFiles writes the files of a challenge under the given directory.
+func (ec *exportedChallenge) writeFiles(dir string) error {
+	if len(ec.files) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, file := range ec.files {
+		if err := os.WriteFile(filepath.Join(dir, file.name), file.content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// region HCL
+
+// exportHCL writes the challenges as ctfdcm_challenge_dynamiciac resources along
+// with their import blocks, and their files under files/<label>/.
+// Prerequisites and next challenges among the exported ones refer to their resource,
+// such that the configuration could be applied to another CTFd. A next challenge is
+// only kept as an identifier if it already depends on the challenge, as referring to
+// it would form a dependency cycle.
+func exportHCL(output string, challs []*exportedChallenge) error {
+	labels := make(map[string]string, len(challs))
+	for _, ec := range challs {
+		labels[ec.ID.ValueString()] = ec.label
+	}
+	refNexts := exportNextReferences(challs, labels)
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for i, ec := range challs {
+		if i != 0 {
+			body.AppendNewline()
+		}
+
+		imp := body.AppendNewBlock("import", nil).Body()
+		imp.SetAttributeTraversal("to", challengeTraversal(ec.label))
+		imp.SetAttributeValue("id", cty.StringVal(ec.ID.ValueString()))
+		body.AppendNewline()
+
+		res := body.AppendNewBlock("resource", []string{"ctfdcm_challenge_dynamiciac", ec.label}).Body()
+		ec.writeHCL(res, labels, refNexts[ec.ID.ValueString()])
+
+		if err := ec.writeFiles(filepath.Join(output, "files", ec.label)); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(output, exportHCLFile), f.Bytes(), 0o644)
+}
+
+// exportNextReferences returns the challenges whose next challenge could refer to
+// its resource, i.e. is exported and does not depend on the challenge.
+func exportNextReferences(challs []*exportedChallenge, labels map[string]string) map[string]bool {
+	deps := map[string][]string{}
+	for _, ec := range challs {
+		if ec.Requirements == nil {
+			continue
+		}
+		for _, preq := range ec.Requirements.Prerequisites {
+			if _, ok := labels[preq.ValueString()]; ok {
+				deps[ec.ID.ValueString()] = append(deps[ec.ID.ValueString()], preq.ValueString())
+			}
+		}
+	}
+
+	// dependsOn reports whether a challenge refers to another, directly or not
+	var dependsOn func(from, to string, seen map[string]bool) bool
+	dependsOn = func(from, to string, seen map[string]bool) bool {
+		if from == to {
+			return true
+		}
+		if seen[from] {
+			return false
+		}
+		seen[from] = true
+		return slices.ContainsFunc(deps[from], func(dep string) bool { return dependsOn(dep, to, seen) })
+	}
+
+	refs := map[string]bool{}
+	for _, ec := range challs {
+		if ec.Next.IsNull() {
+			continue
+		}
+		id, next := ec.ID.ValueString(), strconv.FormatInt(ec.Next.ValueInt64(), 10)
+		if _, ok := labels[next]; !ok || dependsOn(next, id, map[string]bool{}) {
+			continue
+		}
+		deps[id] = append(deps[id], next)
+		refs[id] = true
+	}
+	return refs
+}
+
+// writeHCL writes the challenge attributes. Prerequisites among the exported
+// challenges refer to their resource, as does the next challenge if refNext.
+func (ec *exportedChallenge) writeHCL(body *hclwrite.Body, labels map[string]string, refNext bool) {
+	setString := func(name string, v types.String) {
+		if !v.IsNull() {
+			body.SetAttributeValue(name, cty.StringVal(v.ValueString()))
+		}
+	}
+	setInt64 := func(name string, v types.Int64) {
+		if !v.IsNull() {
+			body.SetAttributeValue(name, cty.NumberIntVal(v.ValueInt64()))
+		}
+	}
+	setStrings := func(name string, vs []types.String) {
+		if len(vs) == 0 {
+			return
+		}
+		vals := make([]cty.Value, 0, len(vs))
+		for _, v := range vs {
+			vals = append(vals, cty.StringVal(v.ValueString()))
+		}
+		body.SetAttributeValue(name, cty.ListVal(vals))
+	}
+
+	setString("name", ec.Name)
+	setString("category", ec.Category)
+	setString("description", ec.Description)
+	setString("attribution", ec.Attribution)
+	setString("connection_info", ec.ConnectionInfo)
+	setInt64("max_attempts", ec.MaxAttempts)
+	setInt64("value", ec.Value)
+	setInt64("decay", ec.Decay)
+	setInt64("minimum", ec.Minimum)
+	setString("function", ec.Function)
+	setString("state", ec.State)
+	if refNext {
+		body.SetAttributeTraversal("next", append(challengeTraversal(labels[strconv.FormatInt(ec.Next.ValueInt64(), 10)]), hcl.TraverseAttr{Name: "id"}))
+	} else {
+		setInt64("next", ec.Next)
+	}
+	if ec.Requirements != nil {
+		preqs := make([]hclwrite.Tokens, 0, len(ec.Requirements.Prerequisites))
+		for _, preq := range ec.Requirements.Prerequisites {
+			if label, ok := labels[preq.ValueString()]; ok {
+				preqs = append(preqs, hclwrite.TokensForTraversal(append(challengeTraversal(label), hcl.TraverseAttr{Name: "id"})))
+				continue
+			}
+			preqs = append(preqs, hclwrite.TokensForValue(cty.StringVal(preq.ValueString())))
+		}
+		body.SetAttributeRaw("requirements", hclwrite.TokensForObject([]hclwrite.ObjectAttrTokens{
+			{Name: hclwrite.TokensForIdentifier("behavior"), Value: hclwrite.TokensForValue(cty.StringVal(ec.Requirements.Behavior.ValueString()))},
+			{Name: hclwrite.TokensForIdentifier("prerequisites"), Value: hclwrite.TokensForTuple(preqs)},
+		}))
+	}
+	setStrings("tags", ec.Tags)
+	setStrings("topics", ec.Topics)
+
+	body.AppendNewline()
+	body.SetAttributeValue("shared", cty.BoolVal(ec.Shared.ValueBool()))
+	body.SetAttributeValue("destroy_on_flag", cty.BoolVal(ec.DestroyOnFlag.ValueBool()))
+	setInt64("mana_cost", ec.ManaCost)
+	setString("scenario", ec.Scenario)
+	setInt64("timeout", ec.Timeout)
+	setString("until", ec.Until)
+	if add := ec.Additional.Elements(); len(add) != 0 {
+		vals := make(map[string]cty.Value, len(add))
+		for k, v := range add {
+			vals[k] = cty.StringVal(v.(types.String).ValueString())
+		}
+		body.SetAttributeValue("additional", cty.MapVal(vals))
+	}
+	setInt64("min", ec.Min)
+	setInt64("max", ec.Max)
+	setString("flag_mode", ec.FlagMode)
+
+	if len(ec.Flags) != 0 {
+		flags := make([]cty.Value, 0, len(ec.Flags))
+		for _, flag := range ec.Flags {
+			flags = append(flags, cty.ObjectVal(map[string]cty.Value{
+				"content": cty.StringVal(flag.Content.ValueString()),
+				"data":    cty.StringVal(flag.Data.ValueString()),
+				"type":    cty.StringVal(flag.Type.ValueString()),
+			}))
+		}
+		body.SetAttributeValue("flags", cty.TupleVal(flags))
+	}
+	if len(ec.Hints) != 0 {
+		hints := make([]cty.Value, 0, len(ec.Hints))
+		for _, hint := range ec.Hints {
+			h := map[string]cty.Value{
+				"content": cty.StringVal(hint.Content.ValueString()),
+				"cost":    cty.NumberIntVal(hint.Cost.ValueInt64()),
+			}
+			if len(hint.Requirements) != 0 {
+				reqs := make([]cty.Value, 0, len(hint.Requirements))
+				for _, req := range hint.Requirements {
+					reqs = append(reqs, cty.NumberIntVal(req.ValueInt64()))
+				}
+				h["requirements"] = cty.TupleVal(reqs)
+			}
+			hints = append(hints, cty.ObjectVal(h))
+		}
+		body.SetAttributeValue("hints", cty.TupleVal(hints))
+	}
+	if len(ec.files) != 0 {
+		files := make([]hclwrite.Tokens, 0, len(ec.files))
+		for _, file := range ec.files {
+			files = append(files, hclwrite.TokensForObject([]hclwrite.ObjectAttrTokens{
+				{Name: hclwrite.TokensForIdentifier("name"), Value: hclwrite.TokensForValue(cty.StringVal(file.name))},
+				{Name: hclwrite.TokensForIdentifier("path"), Value: modulePathTokens("files/" + ec.label + "/" + file.name)},
+			}))
+		}
+		body.SetAttributeRaw("files", hclwrite.TokensForTuple(files))
+	}
+}
+
+func challengeTraversal(label string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: "ctfdcm_challenge_dynamiciac"},
+		hcl.TraverseAttr{Name: label},
+	}
+}
+
+// modulePathTokens returns the "${path.module}/<rel>" template, as hclwrite would
+// escape the interpolation of a string value.
+func modulePathTokens(rel string) hclwrite.Tokens {
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("path.module")},
+		{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")},
+		{Type: hclsyntax.TokenQuotedLit, Bytes: []byte("/" + rel)},
+		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+	}
+}
+
+// region YAML
+
+// exportYAML writes the challenges as ctfcli bundles, each under <label>/ with its
+// files under dist/. Requirements and next challenges are referred to by name, or
+// by identifier when the name is not unique, as ctfdcm_challenge_bundle resolves them.
+func exportYAML(output string, challs []*exportedChallenge, names map[int]string) error {
+	counts := map[string]int{}
+	for _, name := range names {
+		counts[name]++
+	}
+	ref := func(id int) string {
+		if name, ok := names[id]; ok && counts[name] == 1 {
+			if _, err := strconv.Atoi(name); err != nil {
+				return name
+			}
+		}
+		return strconv.Itoa(id)
+	}
+
+	for _, ec := range challs {
+		dir := filepath.Join(output, ec.label)
+		if err := ec.writeFiles(filepath.Join(dir, "dist")); err != nil {
+			return err
+		}
+		b, err := yaml.Marshal(ec.challengeYAML(ref))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, challengeYAMLFile), b, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// challengeYAML converts the challenge to the ctfcli format, the other way around
+// of (*ChallengeYAML).model.
+func (ec *exportedChallenge) challengeYAML(ref func(id int) string) *ChallengeYAML {
+	add := map[string]string{}
+	for k, v := range ec.Additional.Elements() {
+		add[k] = v.(types.String).ValueString()
+	}
+
+	cy := &ChallengeYAML{
+		Name:           ec.Name.ValueString(),
+		Category:       ec.Category.ValueString(),
+		Description:    ec.Description.ValueString(),
+		Attribution:    ec.Attribution.ValueStringPointer(),
+		ConnectionInfo: ec.ConnectionInfo.ValueStringPointer(),
+		Attempts:       utils.ToInt(ec.MaxAttempts),
+		Value:          utils.ToInt(ec.Value),
+		Type:           "dynamic_iac",
+		State:          ec.State.ValueString(),
+		Extra: ChallengeYAMLExtra{
+			Decay:         utils.ToInt(ec.Decay),
+			Minimum:       utils.ToInt(ec.Minimum),
+			Function:      ec.Function.ValueStringPointer(),
+			Shared:        ec.Shared.ValueBool(),
+			DestroyOnFlag: ec.DestroyOnFlag.ValueBool(),
+			ManaCost:      int(ec.ManaCost.ValueInt64()),
+			Scenario:      ec.Scenario.ValueString(),
+			Timeout:       utils.ToInt(ec.Timeout),
+			Until:         ec.Until.ValueStringPointer(),
+			Additional:    add,
+			Min:           int(ec.Min.ValueInt64()),
+			Max:           int(ec.Max.ValueInt64()),
+			FlagMode:      ec.FlagMode.ValueString(),
+		},
+	}
+	if !ec.Next.IsNull() {
+		cy.Next = ref(int(ec.Next.ValueInt64()))
+	}
+	if ec.Requirements != nil {
+		cy.Requirements = &ChallengeYAMLRequirements{
+			Anonymize: ec.Requirements.Behavior.ValueString() == "anonymized",
+		}
+		for _, preq := range ec.Requirements.Prerequisites {
+			cy.Requirements.Prerequisites = append(cy.Requirements.Prerequisites, ref(utils.Atoi(preq.ValueString())))
+		}
+	}
+	for _, tag := range ec.Tags {
+		cy.Tags = append(cy.Tags, tag.ValueString())
+	}
+	for _, topic := range ec.Topics {
+		cy.Topics = append(cy.Topics, topic.ValueString())
+	}
+	for _, flag := range ec.Flags {
+		cy.Flags = append(cy.Flags, ChallengeYAMLFlag{
+			Type:    flag.Type.ValueString(),
+			Content: flag.Content.ValueString(),
+			Data:    flag.Data.ValueString(),
+		})
+	}
+	for _, hint := range ec.Hints {
+		cy.Hints = append(cy.Hints, ChallengeYAMLHint{
+			Content: hint.Content.ValueString(),
+			Cost:    int(hint.Cost.ValueInt64()),
+		})
+	}
+	for _, file := range ec.files {
+		cy.Files = append(cy.Files, "dist/"+file.name)
+	}
+	return cy
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+// newExportedChallenge returns a challenge as read from CTFd, requiring the given
+// challenges and suggesting next the given one if not zero.
+func newExportedChallenge(id int, label string, next int, preqs ...int) *exportedChallenge {
+	chall := &ChallengeDynamicIaCModel{
+		Shared:        types.BoolValue(true),
+		DestroyOnFlag: types.BoolValue(false),
+		ManaCost:      types.Int64Value(2),
+		Scenario:      types.StringValue("registry.lan/scenarios/" + label + ":v0.1.0"),
+		Timeout:       types.Int64Value(600),
+		Until:         types.StringNull(),
+		Additional: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"difficulty": types.StringValue("easy"),
+		}),
+		Min:      types.Int64Value(1),
+		Max:      types.Int64Value(3),
+		FlagMode: types.StringValue("both"),
+		Flags: []ChallengeFlagModel{
+			{
+				ID:      types.StringValue("1"),
+				Content: types.StringValue("CTF{some_flag}"),
+				Data:    types.StringValue("case_insensitive"),
+				Type:    types.StringValue("static"),
+			},
+		},
+		Hints: []ChallengeHintModel{
+			{
+				ID:      types.StringValue("1"),
+				Content: types.StringValue("Look at the headers"),
+				Cost:    types.Int64Value(10),
+			},
+		},
+	}
+	chall.ID = types.StringValue(strconv.Itoa(id))
+	chall.Name = types.StringValue("Challenge " + label)
+	chall.Category = types.StringValue("network")
+	chall.Description = types.StringValue("...")
+	chall.Attribution = types.StringNull()
+	chall.ConnectionInfo = types.StringValue("https://" + label + ".ctfer.io")
+	chall.MaxAttempts = types.Int64Null()
+	chall.Function = types.StringValue("logarithmic")
+	chall.Value = types.Int64Value(500)
+	chall.Decay = types.Int64Value(20)
+	chall.Minimum = types.Int64Value(50)
+	chall.State = types.StringValue("hidden")
+	chall.Tags = []types.String{types.StringValue("web")}
+	chall.Next = types.Int64Null()
+	if next != 0 {
+		chall.Next = types.Int64Value(int64(next))
+	}
+	if len(preqs) != 0 {
+		chall.Requirements = &tfctfd.RequirementsSubresourceModel{
+			Behavior: types.StringValue("anonymized"),
+		}
+		for _, preq := range preqs {
+			chall.Requirements.Prerequisites = append(chall.Requirements.Prerequisites, types.StringValue(strconv.Itoa(preq)))
+		}
+	}
+
+	return &exportedChallenge{
+		ChallengeDynamicIaCModel: chall,
+		label:                    label,
+		files: []exportedFile{
+			{name: "note.txt", content: []byte("Some note.")},
+		},
+	}
+}
+
+func Test_ExportHCL(t *testing.T) {
+	// first requires second, which suggests first next: referring to it would form a
+	// cycle. third suggests first next, and is not required by it.
+	first := newExportedChallenge(1, "first", 0, 2, 99)
+	second := newExportedChallenge(2, "second", 1)
+	third := newExportedChallenge(3, "third", 1)
+
+	output := t.TempDir()
+	if err := exportHCL(output, []*exportedChallenge{first, second, third}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(output, exportHCLFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, diags := hclsyntax.ParseConfig(b, exportHCLFile, hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parsing exported configuration: %s", diags)
+	}
+
+	resources := map[string]hclsyntax.Attributes{}
+	imports := 0
+	for _, block := range f.Body.(*hclsyntax.Body).Blocks {
+		switch block.Type {
+		case "import":
+			imports++
+		case "resource":
+			resources[block.Labels[1]] = block.Body.Attributes
+		}
+	}
+	if imports != 3 || len(resources) != 3 {
+		t.Fatalf("expected 3 import blocks and resources, got %d and %d", imports, len(resources))
+	}
+
+	// references returns the resources an attribute refers to
+	references := func(label, name string) []string {
+		t.Helper()
+		at, ok := resources[label][name]
+		if !ok {
+			t.Fatalf("attribute %s of %s is not set", name, label)
+		}
+		refs := []string{}
+		for _, trav := range at.Expr.Variables() {
+			refs = append(refs, trav.RootName()+"."+trav[1].(hcl.TraverseAttr).Name)
+		}
+		return refs
+	}
+
+	if refs := references("first", "requirements"); len(refs) != 1 || refs[0] != "ctfdcm_challenge_dynamiciac.second" {
+		t.Errorf("expected first to require second by reference, got %v", refs)
+	}
+	if refs := references("second", "next"); len(refs) != 0 {
+		t.Errorf("expected second next to be kept as an identifier, got %v", refs)
+	}
+	if refs := references("third", "next"); len(refs) != 1 || refs[0] != "ctfdcm_challenge_dynamiciac.first" {
+		t.Errorf("expected third next to refer to first, got %v", refs)
+	}
+
+	// Files are written along
+	if _, err := os.Stat(filepath.Join(output, "files", "first", "note.txt")); err != nil {
+		t.Errorf("expected file to be written, got %s", err)
+	}
+}
+
+func Test_ExportedChallenge_ChallengeYAML(t *testing.T) {
+	ec := newExportedChallenge(1, "first", 3, 2)
+	names := map[int]string{2: "Challenge second", 3: "Challenge third"}
+	cy := ec.challengeYAML(func(id int) string { return names[id] })
+
+	if cy.Type != "dynamic_iac" || cy.Next != "Challenge third" {
+		t.Errorf("expected a dynamic_iac challenge with next Challenge third, got %s and %s", cy.Type, cy.Next)
+	}
+	if cy.Requirements == nil || !cy.Requirements.Anonymize || len(cy.Requirements.Prerequisites) != 1 || cy.Requirements.Prerequisites[0] != "Challenge second" {
+		t.Errorf("expected anonymized requirements on Challenge second, got %+v", cy.Requirements)
+	}
+	if len(cy.Files) != 1 || cy.Files[0] != "dist/note.txt" {
+		t.Errorf("expected file dist/note.txt, got %v", cy.Files)
+	}
+
+	// Converting it back must give the same challenge
+	ids := map[string]int{"Challenge second": 2, "Challenge third": 3}
+	chall, err := cy.model("bundle", ec.Scenario.ValueString(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, eq := range map[string]bool{
+		"name":            chall.Name.Equal(ec.Name),
+		"category":        chall.Category.Equal(ec.Category),
+		"connection_info": chall.ConnectionInfo.Equal(ec.ConnectionInfo),
+		"value":           chall.Value.Equal(ec.Value),
+		"decay":           chall.Decay.Equal(ec.Decay),
+		"minimum":         chall.Minimum.Equal(ec.Minimum),
+		"function":        chall.Function.Equal(ec.Function),
+		"state":           chall.State.Equal(ec.State),
+		"next":            chall.Next.Equal(ec.Next),
+		"shared":          chall.Shared.Equal(ec.Shared),
+		"mana_cost":       chall.ManaCost.Equal(ec.ManaCost),
+		"scenario":        chall.Scenario.Equal(ec.Scenario),
+		"timeout":         chall.Timeout.Equal(ec.Timeout),
+		"additional":      chall.Additional.Equal(ec.Additional),
+		"min":             chall.Min.Equal(ec.Min),
+		"max":             chall.Max.Equal(ec.Max),
+		"flag_mode":       chall.FlagMode.Equal(ec.FlagMode),
+	} {
+		if !eq {
+			t.Errorf("expected %s to be kept", name)
+		}
+	}
+	if chall.Requirements == nil || chall.Requirements.Behavior.ValueString() != "anonymized" || len(chall.Requirements.Prerequisites) != 1 || chall.Requirements.Prerequisites[0].ValueString() != "2" {
+		t.Errorf("expected anonymized requirements on challenge 2, got %+v", chall.Requirements)
+	}
+	if len(chall.Flags) != 1 || !chall.Flags[0].Content.Equal(ec.Flags[0].Content) || !chall.Flags[0].Data.Equal(ec.Flags[0].Data) || !chall.Flags[0].Type.Equal(ec.Flags[0].Type) {
+		t.Errorf("expected flags to be kept, got %+v", chall.Flags)
+	}
+	if len(chall.Hints) != 1 || !chall.Hints[0].Content.Equal(ec.Hints[0].Content) || !chall.Hints[0].Cost.Equal(ec.Hints[0].Cost) {
+		t.Errorf("expected hints to be kept, got %+v", chall.Hints)
+	}
+	if len(chall.Files) != 1 || chall.Files[0].Path.ValueString() != filepath.Join("bundle", "dist", "note.txt") {
+		t.Errorf("expected file bundle/dist/note.txt, got %+v", chall.Files)
+	}
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ action.Action              = (*instanceRestartAction)(nil)
+	_ action.ActionWithConfigure = (*instanceRestartAction)(nil)
+	_ action.Action              = (*instanceRenewAction)(nil)
+	_ action.ActionWithConfigure = (*instanceRenewAction)(nil)
+	_ action.Action              = (*instanceDestroyAction)(nil)
+	_ action.ActionWithConfigure = (*instanceDestroyAction)(nil)
+)
+
+func NewInstanceRestartAction() action.Action {
+	return &instanceRestartAction{}
+}
+
+func NewInstanceRenewAction() action.Action {
+	return &instanceRenewAction{}
+}
+
+func NewInstanceDestroyAction() action.Action {
+	return &instanceDestroyAction{}
+}
+
+type InstanceActionModel struct {
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	SourceID    types.String `tfsdk:"source_id"`
+}
+
+// instanceAction contains what is common to all actions on instances.
+type instanceAction struct {
+	fm *Framework
+}
+
+func (a *instanceAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	a.fm = fm
+}
+
+func instanceActionSchema(description string) schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			"challenge_id": schema.StringAttribute{
+				MarkdownDescription: "The challenge of the instance.",
+				Required:            true,
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "The source of the instance.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+// region restart
+
+type instanceRestartAction struct {
+	instanceAction
+}
+
+func (a *instanceRestartAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_restart"
+}
+
+func (a *instanceRestartAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = instanceActionSchema("Restarts an instance, by destroying it then creating it back. This is useful to bounce a broken instance during an event.\n\nIf the instance could not be created back, the source is left without instance and could create a new one from CTFd.")
+}
+
+func (a *instanceRestartAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, a.fm.Tp.Tracer(serviceName), a)
+	defer span.End()
+
+	var data InstanceActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Destroying instance of challenge %s for source %s", data.ChallengeID.ValueString(), data.SourceID.ValueString()),
+	})
+	if _, _, err := a.fm.Client.DeleteAdminInstance(ctx, &ctfdcm.DeleteAdminInstanceParams{
+		ChallengeID: data.ChallengeID.ValueString(),
+		SourceID:    data.SourceID.ValueString(),
+	}, WithTracerProvider(a.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete instance, got error: %s", err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Creating instance of challenge %s for source %s", data.ChallengeID.ValueString(), data.SourceID.ValueString()),
+	})
+	if _, _, err := a.fm.Client.PostAdminInstance(ctx, &ctfdcm.PostAdminInstanceParams{
+		ChallengeID: data.ChallengeID.ValueString(),
+		SourceID:    data.SourceID.ValueString(),
+	}, WithTracerProvider(a.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create instance back once destroyed, got error: %s. The source is left without instance, it could create a new one from CTFd.", err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Instance restarted",
+	})
+}
+
+// region renew
+
+type instanceRenewAction struct {
+	instanceAction
+}
+
+func (a *instanceRenewAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_renew"
+}
+
+func (a *instanceRenewAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = instanceActionSchema("Renews an instance, such that it is not janitored before its challenge timeout is reached again.")
+}
+
+func (a *instanceRenewAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, a.fm.Tp.Tracer(serviceName), a)
+	defer span.End()
+
+	var data InstanceActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Renewing instance of challenge %s for source %s", data.ChallengeID.ValueString(), data.SourceID.ValueString()),
+	})
+	ist, _, err := a.fm.Client.PatchAdminInstance(ctx, &ctfdcm.PatchAdminInstanceParams{
+		ChallengeID: data.ChallengeID.ValueString(),
+		SourceID:    data.SourceID.ValueString(),
+	}, WithTracerProvider(a.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to renew instance, got error: %s", err),
+		)
+		return
+	}
+
+	msg := "Instance renewed"
+	if ist.Until != nil && *ist.Until != "" {
+		msg = fmt.Sprintf("Instance renewed until %s", *ist.Until)
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: msg,
+	})
+}
+
+// region destroy
+
+type instanceDestroyAction struct {
+	instanceAction
+}
+
+func (a *instanceDestroyAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_destroy"
+}
+
+func (a *instanceDestroyAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = instanceActionSchema("Destroys an instance. The source could then create a new one from CTFd.")
+}
+
+func (a *instanceDestroyAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, a.fm.Tp.Tracer(serviceName), a)
+	defer span.End()
+
+	var data InstanceActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Destroying instance of challenge %s for source %s", data.ChallengeID.ValueString(), data.SourceID.ValueString()),
+	})
+	if _, _, err := a.fm.Client.DeleteAdminInstance(ctx, &ctfdcm.DeleteAdminInstanceParams{
+		ChallengeID: data.ChallengeID.ValueString(),
+		SourceID:    data.SourceID.ValueString(),
+	}, WithTracerProvider(a.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete instance, got error: %s", err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Instance destroyed",
+	})
+}
@@ -0,0 +1,144 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_InstanceActions(t *testing.T) {
+	base := providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "chall" {
+	name        = "Some bounced challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "visible"
+
+	scenario = var.scenario
+	timeout  = 600
+}
+
+resource "ctfd_user" "actions" {
+	name     = "Actions"
+	email    = "actions@ctfer.io"
+	password = "password"
+}
+
+resource "ctfd_team" "actions" {
+	name = "Actions"
+	email = "actions-team@ctfer.io"
+	password = "actions"
+	members = [
+	  ctfd_user.actions.id,
+	]
+	captain = ctfd_user.actions.id
+}
+
+variable "scenario" {
+  type = string
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: base + `
+resource "ctfdcm_instance" "ist" {
+	challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+	source_id    = ctfd_team.actions.id
+}
+
+action "ctfdcm_instance_renew" "ist" {
+	config {
+		challenge_id = ctfdcm_instance.ist.challenge_id
+		source_id    = ctfdcm_instance.ist.source_id
+	}
+}
+
+action "ctfdcm_instance_restart" "ist" {
+	config {
+		challenge_id = ctfdcm_instance.ist.challenge_id
+		source_id    = ctfdcm_instance.ist.source_id
+	}
+}
+
+resource "terraform_data" "bounce" {
+	input = ctfdcm_instance.ist.id
+
+	lifecycle {
+		action_trigger {
+			events  = [after_create]
+			actions = [
+				action.ctfdcm_instance_renew.ist,
+				action.ctfdcm_instance_restart.ist,
+			]
+		}
+	}
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+			// Forget the instance without destroying it, such that the action does
+			{
+				Config: base + `
+removed {
+	from = ctfdcm_instance.ist
+
+	lifecycle {
+		destroy = false
+	}
+}
+
+action "ctfdcm_instance_destroy" "ist" {
+	config {
+		challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+		source_id    = ctfd_team.actions.id
+	}
+}
+
+resource "terraform_data" "teardown" {
+	input = ctfd_team.actions.id
+
+	lifecycle {
+		action_trigger {
+			events  = [after_create]
+			actions = [
+				action.ctfdcm_instance_destroy.ist,
+			]
+		}
+	}
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+			// The instance could only be created back if it has been destroyed
+			{
+				Config: base + `
+resource "ctfdcm_instance" "ist" {
+	challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+	source_id    = ctfd_team.actions.id
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ctfdcm_instance.ist", "id"),
+				),
+			},
+		},
+	})
+}
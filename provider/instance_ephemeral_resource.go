@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*instanceEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*instanceEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithRenew     = (*instanceEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*instanceEphemeralResource)(nil)
+)
+
+const (
+	// privateKeyInstance is the private data key holding the instance to renew and close.
+	privateKeyInstance = "instance"
+
+	// instanceRenewMargin is the margin before an instance is janitored to renew it.
+	instanceRenewMargin = time.Minute
+)
+
+func NewInstanceEphemeralResource() ephemeral.EphemeralResource {
+	return &instanceEphemeralResource{}
+}
+
+type instanceEphemeralResource struct {
+	fm *Framework
+}
+
+type InstanceEphemeralResourceModel struct {
+	ChallengeID    types.String `tfsdk:"challenge_id"`
+	SourceID       types.String `tfsdk:"source_id"`
+	ConnectionInfo types.String `tfsdk:"connection_info"`
+	Flag           types.String `tfsdk:"flag"`
+	Until          types.String `tfsdk:"until"`
+}
+
+// instancePrivateData is the instance reference kept between Open, Renew and Close.
+type instancePrivateData struct {
+	ChallengeID string `json:"challenge_id"`
+	SourceID    string `json:"source_id"`
+}
+
+func (r *instanceEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (r *instanceEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An instance of a challenge for a source, that only lives during the Terraform run. It is renewed while in use, and deleted once done with it.\n\nThis is convenient for smoke tests in CI, as the instance does not linger in a state if the pipeline dies.",
+		Attributes: map[string]schema.Attribute{
+			"challenge_id": schema.StringAttribute{
+				MarkdownDescription: "The challenge to provision an instance of.",
+				Required:            true,
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "The source of whom to provision an instance for.",
+				Required:            true,
+			},
+			"connection_info": schema.StringAttribute{
+				MarkdownDescription: "The connection information to reach the instance.",
+				Computed:            true,
+			},
+			"flag": schema.StringAttribute{
+				MarkdownDescription: "The flag of the instance, if its scenario returns one.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"until": schema.StringAttribute{
+				MarkdownDescription: "The date until the instance could run before being janitored, if any.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *instanceEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *instanceEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data InstanceEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ist, _, err := r.fm.Client.PostAdminInstance(ctx, &ctfdcm.PostAdminInstanceParams{
+		ChallengeID: data.ChallengeID.ValueString(),
+		SourceID:    data.SourceID.ValueString(),
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create instance, got error: %s", err),
+		)
+		return
+	}
+
+	data.ConnectionInfo = types.StringValue(ist.ConnectionInfo)
+	data.Flag = types.StringPointerValue(ist.Flag)
+	data.Until = types.StringPointerValue(ist.Until)
+	resp.RenewAt = renewAt(ist.Until, &resp.Diagnostics)
+
+	b, _ := json.Marshal(instancePrivateData{
+		ChallengeID: data.ChallengeID.ValueString(),
+		SourceID:    data.SourceID.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyInstance, b)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *instanceEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	pd := &instancePrivateData{}
+	getPrivateData(ctx, req.Private, privateKeyInstance, pd, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ist, _, err := r.fm.Client.PatchAdminInstance(ctx, &ctfdcm.PatchAdminInstanceParams{
+		ChallengeID: pd.ChallengeID,
+		SourceID:    pd.SourceID,
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to renew instance, got error: %s", err),
+		)
+		return
+	}
+	resp.RenewAt = renewAt(ist.Until, &resp.Diagnostics)
+}
+
+func (r *instanceEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	pd := &instancePrivateData{}
+	getPrivateData(ctx, req.Private, privateKeyInstance, pd, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, _, err := r.fm.Client.DeleteAdminInstance(ctx, &ctfdcm.DeleteAdminInstanceParams{
+		ChallengeID: pd.ChallengeID,
+		SourceID:    pd.SourceID,
+	}, WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete instance, got error: %s", err),
+		)
+		return
+	}
+}
+
+type privateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// getPrivateData decodes the JSON private data under key into v.
+func getPrivateData(ctx context.Context, private privateGetter, key string, v any, diags *diag.Diagnostics) {
+	b, d := private.GetKey(ctx, key)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		diags.AddError(
+			"Provider Error",
+			fmt.Sprintf("Unable to decode %s private data, got error: %s", key, err),
+		)
+	}
+}
+
+// renewAt returns when to renew an instance that is janitored at until.
+// If the instance is not janitored by date, it does not need to be renewed.
+func renewAt(until *string, diags *diag.Diagnostics) time.Time {
+	if until == nil || *until == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, *until)
+	if err != nil {
+		diags.AddWarning(
+			"Unexpected Instance Until",
+			fmt.Sprintf("Unable to parse until date %s, the instance won't be renewed: %s", *until, err),
+		)
+		return time.Time{}
+	}
+	return t.Add(-instanceRenewMargin)
+}
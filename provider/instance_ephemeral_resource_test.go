@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_InstanceEphemeral_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "chall" {
+	name        = "Some ephemeral challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "visible"
+
+	scenario = var.scenario
+}
+
+resource "ctfd_user" "ephemeral" {
+	name     = "Ephemeral"
+	email    = "ephemeral@ctfer.io"
+	password = "password"
+}
+
+resource "ctfd_team" "ephemeral" {
+	name = "Ephemeral"
+	email = "ephemeral-team@ctfer.io"
+	password = "ephemeral"
+	members = [
+	  ctfd_user.ephemeral.id,
+	]
+	captain = ctfd_user.ephemeral.id
+}
+
+ephemeral "ctfdcm_instance" "smoke" {
+	challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+	source_id    = ctfd_team.ephemeral.id
+}
+
+provider "echo" {
+	data = ephemeral.ctfdcm_instance.smoke
+}
+
+resource "echo" "smoke" {}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.smoke", tfjsonpath.New("data").AtMapKey("connection_info"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ list.ListResource              = (*instanceListResource)(nil)
+	_ list.ListResourceWithConfigure = (*instanceListResource)(nil)
+)
+
+func NewInstanceListResource() list.ListResource {
+	return &instanceListResource{}
+}
+
+type instanceListResource struct {
+	fm *Framework
+}
+
+type instanceListResourceModel struct {
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	SourceID    types.String `tfsdk:"source_id"`
+}
+
+func (r *instanceListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (r *instanceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the instances deployed through CTFd-Chall-Manager.",
+		Attributes: map[string]schema.Attribute{
+			"challenge_id": schema.StringAttribute{
+				MarkdownDescription: "Only list the instances of this challenge.",
+				Optional:            true,
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "Only list the instances of this source.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *instanceListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *instanceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+
+	var filter instanceListResourceModel
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		span.End()
+		return
+	}
+
+	ists, _, err := r.fm.Client.GetAdminInstances(ctx, &ctfdcm.GetAdminInstancesParams{
+		ChallengeID: filter.ChallengeID.ValueStringPointer(),
+		SourceID:    filter.SourceID.ValueStringPointer(),
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list instances, got error: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		span.End()
+		return
+	}
+
+	// The results are streamed once returned, so the span ends along
+	stream.Results = func(push func(list.ListResult) bool) {
+		defer span.End()
+
+		for _, ist := range ists {
+			result := req.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("Challenge %s for source %s", ist.ChallengeID, ist.SourceID)
+
+			data := InstanceResourceModel{
+				ID:          types.StringValue(instanceID(ist.ChallengeID, ist.SourceID)),
+				ChallengeID: types.StringValue(ist.ChallengeID),
+				SourceID:    types.StringValue(ist.SourceID),
+				Flag:        types.StringPointerValue(ist.Flag),
+			}
+			result.Diagnostics.Append(result.Identity.Set(ctx, &instanceIdentityModel{
+				ChallengeID: data.ChallengeID,
+				SourceID:    data.SourceID,
+			})...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
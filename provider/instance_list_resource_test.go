@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_InstanceList_Query(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "chall" {
+	name        = "Some listed challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "visible"
+
+	scenario = var.scenario
+}
+
+resource "ctfd_user" "list" {
+	name     = "List"
+	email    = "list@ctfer.io"
+	password = "password"
+}
+
+resource "ctfd_team" "list" {
+	name = "List"
+	email = "list-team@ctfer.io"
+	password = "list"
+	members = [
+	  ctfd_user.list.id,
+	]
+	captain = ctfd_user.list.id
+}
+
+resource "ctfdcm_instance" "ist" {
+	challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+	source_id    = ctfd_team.list.id
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+			{
+				Query: true,
+				Config: providerConfig + `
+list "ctfdcm_instance" "listed" {
+	provider = ctfdcm
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("ctfdcm_instance.listed", 1),
+				},
+			},
+		},
+	})
+}
@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -15,8 +18,11 @@ import (
 )
 
 var (
-	_ resource.Resource              = (*instanceResource)(nil)
-	_ resource.ResourceWithConfigure = (*instanceResource)(nil)
+	_ resource.Resource                 = (*instanceResource)(nil)
+	_ resource.ResourceWithConfigure    = (*instanceResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*instanceResource)(nil)
+	_ resource.ResourceWithIdentity     = (*instanceResource)(nil)
+	_ resource.ResourceWithImportState  = (*instanceResource)(nil)
 )
 
 func NewInstanceResource() resource.Resource {
@@ -28,6 +34,13 @@ type instanceResource struct {
 }
 
 type InstanceResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	SourceID    types.String `tfsdk:"source_id"`
+	Flag        types.String `tfsdk:"flag"`
+}
+
+type instanceIdentityModel struct {
 	ChallengeID types.String `tfsdk:"challenge_id"`
 	SourceID    types.String `tfsdk:"source_id"`
 }
@@ -39,7 +52,15 @@ func (r *instanceResource) Metadata(ctx context.Context, req resource.MetadataRe
 func (r *instanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nThis implementation has support of On Demand infrastructures through [Chall-Manager](https://github.com/ctfer-io/chall-manager).",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the instance, composed of the challenge and source identifiers (`<challenge_id>/<source_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"challenge_id": schema.StringAttribute{
 				MarkdownDescription: "The challenge to provision an instance of.",
 				Required:            true,
@@ -54,6 +75,29 @@ func (r *instanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"flag": schema.StringAttribute{
+				MarkdownDescription: "The flag of the instance, if its scenario returns one. It is validated when the challenge `flag_mode` is either `instance` or `both`.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *instanceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"challenge_id": identityschema.StringAttribute{
+				Description:       "The challenge the instance is provisioned of.",
+				RequiredForImport: true,
+			},
+			"source_id": identityschema.StringAttribute{
+				Description:       "The source the instance is provisioned for.",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -86,10 +130,11 @@ func (r *instanceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	if _, _, err := r.fm.Client.PostAdminInstance(ctx, &ctfdcm.PostAdminInstanceParams{
+	ist, _, err := r.fm.Client.PostAdminInstance(ctx, &ctfdcm.PostAdminInstanceParams{
 		ChallengeID: data.ChallengeID.ValueString(),
 		SourceID:    data.SourceID.ValueString(),
-	}, WithTracerProvider(r.fm.Tp)); err != nil {
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to create instance, got error: %s", err),
@@ -97,10 +142,18 @@ func (r *instanceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Save computed attributes in state
+	data.ID = types.StringValue(instanceID(data.ChallengeID.ValueString(), data.SourceID.ValueString()))
+	data.Flag = types.StringPointerValue(ist.Flag)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &instanceIdentityModel{
+		ChallengeID: data.ChallengeID,
+		SourceID:    data.SourceID,
+	})...)
 }
 
 func (r *instanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -113,21 +166,28 @@ func (r *instanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	if _, _, err := r.fm.Client.GetAdminInstance(ctx, &ctfdcm.GetAdminInstanceParams{
+	ist, _, err := r.fm.Client.GetAdminInstance(ctx, &ctfdcm.GetAdminInstanceParams{
 		ChallengeID: data.ChallengeID.ValueString(),
 		SourceID:    data.SourceID.ValueString(),
-	}, WithTracerProvider(r.fm.Tp)); err != nil {
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to read instance, got error: %s", err),
 		)
 		return
 	}
+	data.ID = types.StringValue(instanceID(data.ChallengeID.ValueString(), data.SourceID.ValueString()))
+	data.Flag = types.StringPointerValue(ist.Flag)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &instanceIdentityModel{
+		ChallengeID: data.ChallengeID,
+		SourceID:    data.SourceID,
+	})...)
 }
 
 func (r *instanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -166,3 +226,75 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 }
+
+func (r *instanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var challengeID, sourceID string
+	if req.ID != "" {
+		var ok bool
+		challengeID, sourceID, ok = strings.Cut(req.ID, "/")
+		if !ok || challengeID == "" || sourceID == "" {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected import identifier with format <challenge_id>/<source_id>, got: %s", req.ID),
+			)
+			return
+		}
+	} else {
+		var identity instanceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		challengeID = identity.ChallengeID.ValueString()
+		sourceID = identity.SourceID.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), instanceID(challengeID, sourceID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("challenge_id"), challengeID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), sourceID)...)
+
+	// Automatically call r.Read
+}
+
+func (r *instanceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"challenge_id": schema.StringAttribute{Required: true},
+					"source_id":    schema.StringAttribute{Required: true},
+				},
+			},
+			StateUpgrader: upgradeInstanceStateV0,
+		},
+	}
+}
+
+// upgradeInstanceStateV0 upgrades a v0 state, which had no `id`, to v1.
+func upgradeInstanceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior instanceResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := InstanceResourceModel{
+		ID:          types.StringValue(instanceID(prior.ChallengeID.ValueString(), prior.SourceID.ValueString())),
+		ChallengeID: prior.ChallengeID,
+		SourceID:    prior.SourceID,
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// instanceResourceModelV0 is the model of the schema version 0.
+// It must not change, as it is used to upgrade states.
+type instanceResourceModelV0 struct {
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	SourceID    types.String `tfsdk:"source_id"`
+}
+
+// instanceID builds the identifier of an instance as CTFd-Chall-Manager
+// uniquely identifies them by their challenge and source.
+func instanceID(challengeID, sourceID string) string {
+	return challengeID + "/" + sourceID
+}
@@ -1,10 +1,16 @@
 package provider_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAcc_Instance_Lifecycle(t *testing.T) {
@@ -58,3 +64,132 @@ variable "scenario" {
 		},
 	})
 }
+
+func TestAcc_Instance_Flag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "chall" {
+	name        = "Some challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "visible"
+
+	scenario  = var.scenario
+	flag_mode = "instance"
+}
+
+resource "ctfd_user" "pandatix" {
+	name     = "PandatiX"
+	email    = "lucastesson@protonmail.com"
+	password = "password"
+}
+
+resource "ctfdcm_instance" "ist" {
+	challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+	source_id = ctfd_user.pandatix.id
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ctfdcm_challenge_dynamiciac.chall", tfjsonpath.New("flag_mode"), knownvalue.StringExact("instance")),
+					statecheck.ExpectKnownValue("ctfdcm_instance.ist", tfjsonpath.New("flag"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func Test_Instance_UpgradeStateV0(t *testing.T) {
+	state := upgradeState(t, provider.NewInstanceResource(), 0, "instance_v0.json")
+
+	var data provider.InstanceResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("decoding upgraded state: %v", diags)
+	}
+
+	if data.ID.ValueString() != "1/2" {
+		t.Errorf("expected id 1/2, got %s", data.ID)
+	}
+	if data.ChallengeID.ValueString() != "1" || data.SourceID.ValueString() != "2" {
+		t.Errorf("expected challenge_id and source_id to be kept, got %s and %s", data.ChallengeID, data.SourceID)
+	}
+}
+
+func TestAcc_Instance_Identity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfdcm_challenge_dynamiciac" "chall" {
+	name        = "Some identified challenge"
+	category    = "cat"
+	description = "..."
+	value       = 500
+    decay       = 20
+    minimum     = 50
+    state       = "visible"
+
+	scenario = var.scenario
+}
+
+resource "ctfd_user" "identity" {
+	name     = "Identity"
+	email    = "identity@ctfer.io"
+	password = "password"
+}
+
+resource "ctfd_team" "identity" {
+	name = "Identity"
+	email = "identity-team@ctfer.io"
+	password = "identity"
+	members = [
+	  ctfd_user.identity.id,
+	]
+	captain = ctfd_user.identity.id
+}
+
+resource "ctfdcm_instance" "ist" {
+	challenge_id = ctfdcm_challenge_dynamiciac.chall.id
+	source_id    = ctfd_team.identity.id
+}
+
+variable "scenario" {
+  type = string
+}
+`,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("ctfdcm_instance.ist", tfjsonpath.New("challenge_id")),
+					statecheck.ExpectIdentityValueMatchesState("ctfdcm_instance.ist", tfjsonpath.New("source_id")),
+				},
+			},
+			// Import through identity
+			{
+				ResourceName:    "ctfdcm_instance.ist",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				ConfigVariables: config.Variables{
+					"scenario": config.StringVariable(ref),
+				},
+			},
+		},
+	})
+}
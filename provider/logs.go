@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+// The logs are written through tflog, and emitted along through the global logger
+// provider set by SetupOTelSDK, if logs are exported. The latter carry the trace and
+// span identifiers of their context, such that they are correlated with the traces.
+var otelLogger = global.Logger(serviceName)
+
+const redacted = "***"
+
+// logFieldsKey is the context key of the fields set on the logs, as tflog does not
+// expose them.
+type logFieldsKey struct{}
+
+type logField struct {
+	value     any
+	sensitive bool
+}
+
+func withLogField(ctx context.Context, key string, field logField) context.Context {
+	fields := map[string]logField{}
+	if prev, ok := ctx.Value(logFieldsKey{}).(map[string]logField); ok {
+		maps.Copy(fields, prev)
+	}
+	fields[key] = field
+	return context.WithValue(ctx, logFieldsKey{}, fields)
+}
+
+// logSetField sets a field on the logs of the context, as tflog.SetField does.
+func logSetField(ctx context.Context, key string, value any) context.Context {
+	ctx = tflog.SetField(ctx, key, value)
+	return withLogField(ctx, key, logField{value: value})
+}
+
+// logAddSensitive sets a sensitive field on the logs of the context, as
+// utils.AddSensitive does. Its value is redacted from the OpenTelemetry logs too.
+func logAddSensitive(ctx context.Context, key, value string) context.Context {
+	ctx = utils.AddSensitive(ctx, key, value)
+	return withLogField(ctx, key, logField{value: value, sensitive: true})
+}
+
+func logTrace(ctx context.Context, msg string, additionalFields ...map[string]any) {
+	tflog.Trace(ctx, msg, additionalFields...)
+	logEmit(ctx, log.SeverityTrace, msg, additionalFields...)
+}
+
+func logDebug(ctx context.Context, msg string, additionalFields ...map[string]any) {
+	tflog.Debug(ctx, msg, additionalFields...)
+	logEmit(ctx, log.SeverityDebug, msg, additionalFields...)
+}
+
+func logInfo(ctx context.Context, msg string, additionalFields ...map[string]any) {
+	tflog.Info(ctx, msg, additionalFields...)
+	logEmit(ctx, log.SeverityInfo, msg, additionalFields...)
+}
+
+func logEmit(ctx context.Context, severity log.Severity, msg string, additionalFields ...map[string]any) {
+	if !otelLogger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	fields, _ := ctx.Value(logFieldsKey{}).(map[string]logField)
+
+	// Sensitive values are masked wherever they show up, as tflog does
+	secrets := []string{}
+	for _, f := range fields {
+		if s, ok := f.value.(string); f.sensitive && ok && s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	redact := func(s string) string {
+		for _, secret := range secrets {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+		return s
+	}
+
+	var rec log.Record
+	rec.SetTimestamp(time.Now())
+	rec.SetSeverity(severity)
+	rec.SetSeverityText(severity.String())
+	rec.SetBody(log.StringValue(redact(msg)))
+	for k, f := range fields {
+		if f.sensitive {
+			rec.AddAttributes(log.String(k, redacted))
+			continue
+		}
+		rec.AddAttributes(log.KeyValue{Key: k, Value: logValue(f.value, redact)})
+	}
+	for _, add := range additionalFields {
+		for k, v := range add {
+			rec.AddAttributes(log.KeyValue{Key: k, Value: logValue(v, redact)})
+		}
+	}
+	otelLogger.Emit(ctx, rec)
+}
+
+func logValue(v any, redact func(string) string) log.Value {
+	switch v := v.(type) {
+	case string:
+		return log.StringValue(redact(v))
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int64:
+		return log.Int64Value(v)
+	case float64:
+		return log.Float64Value(v)
+	default:
+		return log.StringValue(redact(fmt.Sprint(v)))
+	}
+}
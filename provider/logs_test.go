@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// memoryExporter keeps the exported logs in memory.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+var _ sdklog.Exporter = (*memoryExporter)(nil)
+
+func (e *memoryExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, rec := range records {
+		e.records = append(e.records, rec.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(ctx context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func Test_LogEmit_Sensitive(t *testing.T) {
+	exp := &memoryExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+	prev := otelLogger
+	otelLogger = lp.Logger(serviceName)
+	t.Cleanup(func() {
+		otelLogger = prev
+	})
+
+	// Logs are emitted under an active span, to be correlated with it
+	tp := sdktrace.NewTracerProvider()
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+	})
+	ctx, span := tp.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	const secret = "s3cr3t-k3y"
+	ctx = logAddSensitive(ctx, "api_key", secret)
+	ctx = logSetField(ctx, "url", "https://ctfd.ctfer.io")
+	logInfo(ctx, "Logging in with "+secret, map[string]any{
+		"header": "Authorization: Token " + secret,
+	})
+
+	if len(exp.records) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(exp.records))
+	}
+	rec := exp.records[0]
+
+	sc := span.SpanContext()
+	if rec.TraceID() != sc.TraceID() || rec.SpanID() != sc.SpanID() {
+		t.Errorf("expected the log to be correlated with span %s/%s, got %s/%s", sc.TraceID(), sc.SpanID(), rec.TraceID(), rec.SpanID())
+	}
+
+	if body := rec.Body().AsString(); strings.Contains(body, secret) || !strings.Contains(body, redacted) {
+		t.Errorf("expected the secret to be redacted from the body, got %q", body)
+	}
+	attrs := map[string]string{}
+	rec.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	for k, v := range attrs {
+		if strings.Contains(v, secret) {
+			t.Errorf("expected the secret to be redacted from attribute %s, got %q", k, v)
+		}
+	}
+	if attrs["api_key"] != redacted {
+		t.Errorf("expected the sensitive field to be redacted, got %q", attrs["api_key"])
+	}
+	if attrs["url"] != "https://ctfd.ctfer.io" {
+		t.Errorf("expected the other fields to be kept, got %q", attrs["url"])
+	}
+}
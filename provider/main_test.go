@@ -11,6 +11,7 @@ import (
 	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
 	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
 var (
@@ -34,7 +35,18 @@ func TestMain(m *testing.M) {
 	}()
 
 	testAccProtoV6ProviderFactories["ctfd"] = providerserver.NewProtocol6WithError(tfctfd.New("test", out.TracerProvider)())
-	testAccProtoV6ProviderFactories["ctfdcm"] = providerserver.NewProtocol6WithError(provider.New("test", out.TracerProvider)())
+	testAccProtoV6ProviderFactories["ctfdcm"] = func() (tfprotov6.ProviderServer, error) {
+		server, err := providerserver.NewProtocol6WithError(provider.New("test", out.TracerProvider)())()
+		return provider.NewMetricsProviderServer(server), err
+	}
+
+	// Acceptance tests are skipped without TF_ACC, so the scenario is not needed
+	if _, ok := os.LookupEnv("TF_ACC"); !ok {
+		if sc := m.Run(); sc != 0 {
+			log.Fatalf("Failed with status code %d", sc)
+		}
+		return
+	}
 
 	// Build and push test scenario
 	r, ok := os.LookupEnv("REGISTRY")
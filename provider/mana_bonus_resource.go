@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ resource.Resource                = (*manaBonusResource)(nil)
+	_ resource.ResourceWithConfigure   = (*manaBonusResource)(nil)
+	_ resource.ResourceWithIdentity    = (*manaBonusResource)(nil)
+	_ resource.ResourceWithImportState = (*manaBonusResource)(nil)
+)
+
+func NewManaBonusResource() resource.Resource {
+	return &manaBonusResource{}
+}
+
+type manaBonusResource struct {
+	fm *Framework
+}
+
+type ManaBonusResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	SourceID types.String `tfsdk:"source_id"`
+	Bonus    types.Int64  `tfsdk:"bonus"`
+}
+
+type manaBonusIdentityModel struct {
+	SourceID types.String `tfsdk:"source_id"`
+}
+
+func (r *manaBonusResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mana_bonus"
+}
+
+func (r *manaBonusResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A mana bonus granted to a source (user or team) on top of the global mana total, e.g. for sponsor teams to run more instances at once.\n\nDestroying it resets the bonus of the source to zero.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the mana bonus, equal to the source identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "The source (user or team) to grant the bonus to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bonus": schema.Int64Attribute{
+				MarkdownDescription: "The mana granted to the source on top of the global mana total.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *manaBonusResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"source_id": identityschema.StringAttribute{
+				Description:       "The source the bonus is granted to.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *manaBonusResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *manaBonusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ManaBonusResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, _, err := r.fm.Client.PatchAdminMana(ctx, &ctfdcm.PatchAdminManaParams{
+		SourceID: data.SourceID.ValueString(),
+		Bonus:    int(data.Bonus.ValueInt64()),
+	}, WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to grant mana bonus to source %s, got error: %s", data.SourceID.ValueString(), err),
+		)
+		return
+	}
+
+	// Save computed attributes in state
+	data.ID = data.SourceID
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &manaBonusIdentityModel{
+		SourceID: data.SourceID,
+	})...)
+}
+
+func (r *manaBonusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ManaBonusResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mana, _, err := r.fm.Client.GetAdminMana(ctx, &ctfdcm.GetAdminManaParams{
+		SourceID: data.SourceID.ValueString(),
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read mana of source %s, got error: %s", data.SourceID.ValueString(), err),
+		)
+		return
+	}
+	data.ID = data.SourceID
+	data.Bonus = types.Int64Value(int64(mana.Bonus))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &manaBonusIdentityModel{
+		SourceID: data.SourceID,
+	})...)
+}
+
+func (r *manaBonusResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ManaBonusResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, _, err := r.fm.Client.PatchAdminMana(ctx, &ctfdcm.PatchAdminManaParams{
+		SourceID: data.SourceID.ValueString(),
+		Bonus:    int(data.Bonus.ValueInt64()),
+	}, WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update mana bonus of source %s, got error: %s", data.SourceID.ValueString(), err),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *manaBonusResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data ManaBonusResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The mana of a source could not be deleted, so reset its bonus
+	if _, _, err := r.fm.Client.PatchAdminMana(ctx, &ctfdcm.PatchAdminManaParams{
+		SourceID: data.SourceID.ValueString(),
+		Bonus:    0,
+	}, WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to reset mana bonus of source %s, got error: %s", data.SourceID.ValueString(), err),
+		)
+		return
+	}
+}
+
+func (r *manaBonusResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("source_id"), path.Root("source_id"), req, resp)
+
+	// Automatically call r.Read
+}
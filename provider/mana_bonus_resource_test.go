@@ -0,0 +1,100 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_ManaBonus_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_user" "sponsor" {
+	name     = "Sponsor"
+	email    = "sponsor@ctfer.io"
+	password = "password"
+}
+
+resource "ctfd_team" "sponsor" {
+	name     = "Sponsor"
+	email    = "sponsor-team@ctfer.io"
+	password = "sponsor"
+	members = [
+	  ctfd_user.sponsor.id,
+	]
+	captain = ctfd_user.sponsor.id
+}
+
+resource "ctfdcm_mana_bonus" "sponsor" {
+	source_id = ctfd_team.sponsor.id
+	bonus     = 5
+}
+
+data "ctfdcm_mana" "sponsor" {
+	source_id = ctfdcm_mana_bonus.sponsor.source_id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ctfdcm_mana_bonus.sponsor", "id", "ctfd_team.sponsor", "id"),
+					resource.TestCheckResourceAttr("data.ctfdcm_mana.sponsor", "bonus", "5"),
+					resource.TestCheckResourceAttr("data.ctfdcm_mana.sponsor", "used", "0"),
+					resource.TestCheckResourceAttrPair("data.ctfdcm_mana.sponsor", "remaining", "data.ctfdcm_mana.sponsor", "total"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("ctfdcm_mana_bonus.sponsor", tfjsonpath.New("source_id")),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfdcm_mana_bonus.sponsor",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import through identity
+			{
+				ResourceName:    "ctfdcm_mana_bonus.sponsor",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_user" "sponsor" {
+	name     = "Sponsor"
+	email    = "sponsor@ctfer.io"
+	password = "password"
+}
+
+resource "ctfd_team" "sponsor" {
+	name     = "Sponsor"
+	email    = "sponsor-team@ctfer.io"
+	password = "sponsor"
+	members = [
+	  ctfd_user.sponsor.id,
+	]
+	captain = ctfd_user.sponsor.id
+}
+
+resource "ctfdcm_mana_bonus" "sponsor" {
+	source_id = ctfd_team.sponsor.id
+	bonus     = 10
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_mana_bonus.sponsor", "bonus", "10"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
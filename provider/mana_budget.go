@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+
+	ctfd "github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+var providerManaAttributes = map[string]schema.Attribute{
+	"mana_budget_check": schema.BoolAttribute{
+		MarkdownDescription: "Whether to check, during plan, that the `mana_cost` of all the `dynamic_iac` challenges fits in the global mana total, such that a source could run all instances at once. The challenges are fetched once per run to do so, and each planned `ctfdcm_challenge_dynamiciac` costing mana warns otherwise, listing them all.",
+		Optional:            true,
+	},
+}
+
+// manaBudget holds the global mana total and the mana cost of all the dynamic_iac
+// challenges, loaded once per provider run.
+type manaBudget struct {
+	once  sync.Once
+	err   error
+	total int
+	costs map[int]challengeManaCost
+}
+
+type challengeManaCost struct {
+	Name string
+	Cost int
+}
+
+// load fetches the mana total and the challenges mana costs, if not already.
+func (mb *manaBudget) load(ctx context.Context, fm *Framework) error {
+	mb.once.Do(func() {
+		settings, _, err := fm.Client.GetAdminSettings(ctx, WithTracerProvider(fm.Tp))
+		if err != nil {
+			mb.err = fmt.Errorf("getting settings: %w", err)
+			return
+		}
+
+		challs, _, err := fm.Client.GetChallenges(ctx, &ctfd.GetChallengesParams{
+			Type: utils.Ptr("dynamic_iac"),
+		}, WithTracerProvider(fm.Tp))
+		if err != nil {
+			mb.err = fmt.Errorf("getting challenges: %w", err)
+			return
+		}
+		costs := make(map[int]challengeManaCost, len(challs))
+		for _, c := range challs {
+			sid := strconv.Itoa(c.ID)
+			chall, _, err := fm.Client.GetChallenge(ctx, sid, WithTracerProvider(fm.Tp))
+			if err != nil {
+				mb.err = fmt.Errorf("getting challenge %s: %w", sid, err)
+				return
+			}
+			costs[c.ID] = challengeManaCost{
+				Name: chall.Name,
+				Cost: chall.ManaCost,
+			}
+		}
+
+		mb.total = settings.ManaTotal
+		mb.costs = costs
+	})
+	return mb.err
+}
+
+// plan returns the mana cost of all the challenges, the planned one replacing the
+// existing one of the same identifier, if any (challenges not created yet have none).
+// If it exceeds the total, the challenges costing mana are returned too.
+func (mb *manaBudget) plan(id int, planned challengeManaCost) (int, []challengeManaCost) {
+	costs := make(map[int]challengeManaCost, len(mb.costs)+1)
+	maps.Copy(costs, mb.costs)
+	costs[id] = planned
+
+	sum := 0
+	for _, c := range costs {
+		sum += c.Cost
+	}
+	if mb.total == 0 || sum <= mb.total {
+		return sum, nil
+	}
+
+	challs := slices.Collect(maps.Values(costs))
+	challs = slices.DeleteFunc(challs, func(c challengeManaCost) bool { return c.Cost == 0 })
+	slices.SortFunc(challs, func(a, b challengeManaCost) int {
+		return cmp.Or(cmp.Compare(b.Cost, a.Cost), strings.Compare(a.Name, b.Name))
+	})
+	return sum, challs
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func Test_ChallengeDynamicIaC_CheckManaBudget(t *testing.T) {
+	ctx := context.Background()
+
+	// A challenge managed elsewhere, e.g. by a bundle, costs mana too
+	mb := &manaBudget{
+		total: 10,
+		costs: map[int]challengeManaCost{
+			1: {Name: "first", Cost: 4},
+			2: {Name: "bundled", Cost: 3},
+			3: {Name: "free", Cost: 0},
+		},
+	}
+	mb.once.Do(func() {}) // the mana costs are already known
+	r := &challengeDynamicIaCResource{
+		fm: &Framework{
+			ManaBudgetCheck: true,
+			manaBudget:      mb,
+		},
+	}
+
+	var sch resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sch)
+
+	var tests = map[string]struct {
+		ID       types.String
+		Name     string
+		Cost     int64
+		Expected []string
+	}{
+		"create-within": {
+			ID:   types.StringUnknown(),
+			Name: "second",
+			Cost: 3,
+		},
+		"create-exceeding": {
+			ID:       types.StringUnknown(),
+			Name:     "second",
+			Cost:     4,
+			Expected: []string{"first: 4", "second: 4", "bundled: 3"},
+		},
+		"update-within": {
+			ID:   types.StringValue("1"),
+			Name: "first",
+			Cost: 7,
+		},
+		"update-exceeding": {
+			ID:       types.StringValue("1"),
+			Name:     "first",
+			Cost:     8,
+			Expected: []string{"first: 8", "bundled: 3"},
+		},
+		"free": {
+			ID:   types.StringValue("3"),
+			Name: "free",
+			Cost: 0,
+		},
+	}
+
+	// Each plan is checked on its own, whatever the order
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			var chall ChallengeDynamicIaCResourceModel
+			chall.ID = tt.ID
+			chall.Name = types.StringValue(tt.Name)
+			chall.ManaCost = types.Int64Value(tt.Cost)
+			chall.Additional = types.MapNull(types.StringType)
+			chall.TagsAll = types.SetNull(types.StringType)
+			chall.TopicsAll = types.SetNull(types.StringType)
+
+			plan := tfsdk.Plan{
+				Schema: sch.Schema,
+				Raw:    tftypes.NewValue(sch.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.Set(ctx, &chall); diags.HasError() {
+				t.Fatalf("setting plan: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.checkManaBudget(ctx, resource.ModifyPlanRequest{Plan: plan}, resp)
+			diags := resp.Diagnostics
+
+			if tt.Expected == nil {
+				if diags.WarningsCount() != 0 {
+					t.Errorf("expected no warning, got %v", diags)
+				}
+				return
+			}
+			if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Mana Budget Exceeded" {
+				t.Fatalf("expected a mana budget warning, got %v", diags)
+			}
+			want := "  - " + strings.Join(tt.Expected, "\n  - ")
+			if detail := diags.Warnings()[0].Detail(); !strings.HasSuffix(detail, want) {
+				t.Errorf("expected the warning to list %v, got %q", tt.Expected, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = (*manaDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*manaDataSource)(nil)
+)
+
+func NewManaDataSource() datasource.DataSource {
+	return &manaDataSource{}
+}
+
+type manaDataSource struct {
+	fm *Framework
+}
+
+type manaDataSourceModel struct {
+	SourceID  types.String `tfsdk:"source_id"`
+	Total     types.Int64  `tfsdk:"total"`
+	Bonus     types.Int64  `tfsdk:"bonus"`
+	Used      types.Int64  `tfsdk:"used"`
+	Remaining types.Int64  `tfsdk:"remaining"`
+}
+
+func (data *manaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mana"
+}
+
+func (data *manaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The mana of a source (user or team), i.e. how much it could spend on instances at once.",
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "The source (user or team) to get the mana of.",
+				Required:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "The total mana of the source, including its bonus.",
+				Computed:            true,
+			},
+			"bonus": schema.Int64Attribute{
+				MarkdownDescription: "The mana granted to the source on top of the global mana total.",
+				Computed:            true,
+			},
+			"used": schema.Int64Attribute{
+				MarkdownDescription: "The mana currently spent by the source on its instances.",
+				Computed:            true,
+			},
+			"remaining": schema.Int64Attribute{
+				MarkdownDescription: "The mana the source could still spend.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (data *manaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	data.fm = fm
+}
+
+func (data *manaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, data.fm.Tp.Tracer(serviceName), data)
+	defer span.End()
+
+	var state manaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mana, _, err := data.fm.Client.GetAdminMana(ctx, &ctfdcm.GetAdminManaParams{
+		SourceID: state.SourceID.ValueString(),
+	}, WithTracerProvider(data.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read mana of source %s, got error: %s", state.SourceID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Total = types.Int64Value(int64(mana.Total))
+	state.Bonus = types.Int64Value(int64(mana.Bonus))
+	state.Used = types.Int64Value(int64(mana.Used))
+	state.Remaining = types.Int64Value(int64(mana.Total - mana.Used))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
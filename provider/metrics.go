@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The instruments are created from the global meter provider, set by SetupOTelSDK.
+// Until then, or if it is never called, they do not record anything.
+var (
+	apiRequests  metric.Int64Counter
+	apiRetries   metric.Int64Counter
+	apiDuration  metric.Float64Histogram
+	tfOperations metric.Int64Counter
+	tfDuration   metric.Float64Histogram
+)
+
+func init() {
+	setMeter(otel.Meter(serviceName))
+}
+
+// setMeter creates the instruments from the given meter.
+func setMeter(meter metric.Meter) {
+	apiRequests, _ = meter.Int64Counter("ctfd.api.requests",
+		metric.WithDescription("Number of requests sent to CTFd, including the chall-manager plugin ones."),
+		metric.WithUnit("{request}"),
+	)
+	apiRetries, _ = meter.Int64Counter("ctfd.api.retries",
+		metric.WithDescription("Number of requests sent again to the same CTFd endpoint within a single API call."),
+		metric.WithUnit("{request}"),
+	)
+	apiDuration, _ = meter.Float64Histogram("ctfd.api.duration",
+		metric.WithDescription("Duration of the requests sent to CTFd, including the chall-manager plugin ones."),
+		metric.WithUnit("s"),
+	)
+	tfOperations, _ = meter.Int64Counter("terraform.operations",
+		metric.WithDescription("Number of Terraform operations handled by the provider."),
+		metric.WithUnit("{operation}"),
+	)
+	tfDuration, _ = meter.Float64Histogram("terraform.operation.duration",
+		metric.WithDescription("Duration of the Terraform operations handled by the provider."),
+		metric.WithUnit("s"),
+	)
+}
+
+const (
+	attrTFResourceType = attribute.Key("terraform.resource_type")
+	attrTFOperation    = attribute.Key("terraform.operation")
+	attrTFError        = attribute.Key("terraform.error")
+)
+
+// region API
+
+// apiCallKey is the context key of a single API call.
+type apiCallKey struct{}
+
+// apiCall holds the span of an API call, such that the HTTP status it ends with is
+// set on it, and the attempts made to each endpoint, such that retries are told
+// apart from the first attempt.
+type apiCall struct {
+	span trace.Span
+
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func withAPICall(ctx context.Context) context.Context {
+	return context.WithValue(ctx, apiCallKey{}, &apiCall{
+		span:     trace.SpanFromContext(ctx),
+		attempts: map[string]int{},
+	})
+}
+
+// metricsTransport records the requests sent to CTFd.
+type metricsTransport struct {
+	next http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLTemplate(apiEndpoint(req.URL.Path)),
+	}
+	call, ok := ctx.Value(apiCallKey{}).(*apiCall)
+	if ok {
+		key := req.Method + " " + req.URL.Path
+		call.mu.Lock()
+		call.attempts[key]++
+		retry := call.attempts[key] > 1
+		call.mu.Unlock()
+		if retry {
+			apiRetries.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil:
+		attrs = append(attrs, semconv.ErrorTypeOther)
+	case res.StatusCode >= 400:
+		attrs = append(attrs,
+			semconv.HTTPResponseStatusCode(res.StatusCode),
+			semconv.ErrorTypeKey.String(strconv.Itoa(res.StatusCode)),
+		)
+	default:
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(res.StatusCode))
+	}
+	if ok && res != nil {
+		call.span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	}
+	set := metric.WithAttributes(attrs...)
+	apiRequests.Add(ctx, 1, set)
+	apiDuration.Record(ctx, time.Since(start).Seconds(), set)
+	return res, err
+}
+
+// apiEndpoint returns the endpoint of a path, with the identifiers and file
+// locations replaced such that the metrics cardinality remains low.
+func apiEndpoint(pth string) string {
+	if strings.HasPrefix(pth, "/files/") {
+		return "/files/{location}"
+	}
+	segs := strings.Split(pth, "/")
+	for i, seg := range segs {
+		if _, err := strconv.Atoi(seg); err == nil {
+			segs[i] = "{id}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// region Terraform
+
+// metricsProviderServer records the Terraform operations handled by the provider.
+type metricsProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+// NewMetricsProviderServer wraps a provider server to record the duration and errors
+// of its Terraform operations, per resource type.
+func NewMetricsProviderServer(server tfprotov6.ProviderServer) tfprotov6.ProviderServer {
+	return &metricsProviderServer{
+		ProviderServer: server,
+	}
+}
+
+func (s *metricsProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	start := time.Now()
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	recordOperation(ctx, req.TypeName, "plan", start, diags, err)
+	return resp, err
+}
+
+func (s *metricsProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	op := "update"
+	switch {
+	case isNullValue(req.PriorState):
+		op = "create"
+	case isNullValue(req.PlannedState):
+		op = "delete"
+	}
+
+	start := time.Now()
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	recordOperation(ctx, req.TypeName, op, start, diags, err)
+	return resp, err
+}
+
+func (s *metricsProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	start := time.Now()
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	recordOperation(ctx, req.TypeName, "read", start, diags, err)
+	return resp, err
+}
+
+func (s *metricsProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	start := time.Now()
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	recordOperation(ctx, req.TypeName, "import", start, diags, err)
+	return resp, err
+}
+
+func (s *metricsProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	start := time.Now()
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	recordOperation(ctx, req.TypeName, "read", start, diags, err)
+	return resp, err
+}
+
+func (s *metricsProviderServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	start := time.Now()
+	resp, err := s.ProviderServer.OpenEphemeralResource(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	recordOperation(ctx, req.TypeName, "open", start, diags, err)
+	return resp, err
+}
+
+func recordOperation(ctx context.Context, typeName, op string, start time.Time, diags []*tfprotov6.Diagnostic, err error) {
+	failed := err != nil
+	for _, d := range diags {
+		if d != nil && d.Severity == tfprotov6.DiagnosticSeverityError {
+			failed = true
+			break
+		}
+	}
+	set := metric.WithAttributes(
+		attrTFResourceType.String(typeName),
+		attrTFOperation.String(op),
+		attrTFError.Bool(failed),
+	)
+	tfOperations.Add(ctx, 1, set)
+	tfDuration.Record(ctx, time.Since(start).Seconds(), set)
+}
+
+// isNullValue returns whether a state is null, as it is when a resource is created
+// (prior state) or destroyed (planned state).
+func isNullValue(v *tfprotov6.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	if len(v.MsgPack) != 0 {
+		// MessagePack encodes nil as a single byte
+		return bytes.Equal(v.MsgPack, []byte{0xc0})
+	}
+	return len(v.JSON) == 0 || bytes.Equal(bytes.TrimSpace(v.JSON), []byte("null"))
+}
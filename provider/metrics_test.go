@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newManualReader records the instruments in a reader collected on demand, until
+// the test ends.
+func newManualReader(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	setMeter(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter(serviceName))
+	t.Cleanup(func() {
+		setMeter(otel.Meter(serviceName))
+	})
+	return reader
+}
+
+// counted returns the values of a counter, by the value of the given attribute.
+func counted(t *testing.T, reader *sdkmetric.ManualReader, name string, key attribute.Key) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("expected %s to be an int64 sum, got %T", name, m.Data)
+			}
+			for _, dp := range sum.DataPoints {
+				v, _ := dp.Attributes.Value(key)
+				values[v.Emit()] += dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func Test_APIEndpoint(t *testing.T) {
+	var tests = map[string]struct {
+		Path     string
+		Expected string
+	}{
+		"collection": {
+			Path:     "/api/v1/challenges",
+			Expected: "/api/v1/challenges",
+		},
+		"identifier": {
+			Path:     "/api/v1/challenges/12/flags",
+			Expected: "/api/v1/challenges/{id}/flags",
+		},
+		"plugin": {
+			Path:     "/api/v1/plugins/ctfd-chall-manager/admin/instance",
+			Expected: "/api/v1/plugins/ctfd-chall-manager/admin/instance",
+		},
+		"file": {
+			Path:     "/files/0a1b2c3d/note.txt",
+			Expected: "/files/{location}",
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			if got := apiEndpoint(tt.Path); got != tt.Expected {
+				t.Errorf("expected %s, got %s", tt.Expected, got)
+			}
+		})
+	}
+}
+
+func Test_MetricsTransport_Retries(t *testing.T) {
+	reader := newManualReader(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: &metricsTransport{next: http.DefaultTransport},
+	}
+	get := func(ctx context.Context, pth string) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+pth, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+	}
+
+	// Within an API call, only the requests sent again to the same endpoint are retries
+	call := withAPICall(context.Background())
+	get(call, "/api/v1/challenges/1")
+	get(call, "/api/v1/challenges/1")
+	get(call, "/api/v1/challenges/2")
+
+	// Requests out of an API call are never considered as retries
+	get(context.Background(), "/api/v1/challenges/1")
+
+	key := attribute.Key("url.template")
+	requests := counted(t, reader, "ctfd.api.requests", key)
+	if requests["/api/v1/challenges/{id}"] != 4 {
+		t.Errorf("expected 4 requests, got %v", requests)
+	}
+	retries := counted(t, reader, "ctfd.api.retries", key)
+	if retries["/api/v1/challenges/{id}"] != 1 {
+		t.Errorf("expected 1 retry, got %v", retries)
+	}
+}
+
+func Test_IsNullValue(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+	dynamicValue := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(typ, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dv
+	}
+
+	var tests = map[string]struct {
+		Value    *tfprotov6.DynamicValue
+		Expected bool
+	}{
+		"nil": {
+			Value:    nil,
+			Expected: true,
+		},
+		"msgpack-null": {
+			Value:    dynamicValue(tftypes.NewValue(typ, nil)),
+			Expected: true,
+		},
+		"msgpack-object": {
+			Value: dynamicValue(tftypes.NewValue(typ, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "1"),
+			})),
+			Expected: false,
+		},
+		"json-null": {
+			Value:    &tfprotov6.DynamicValue{JSON: []byte(" null\n")},
+			Expected: true,
+		},
+		"json-object": {
+			Value:    &tfprotov6.DynamicValue{JSON: []byte(`{"id":"1"}`)},
+			Expected: false,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			if got := isNullValue(tt.Value); got != tt.Expected {
+				t.Errorf("expected %t, got %t", tt.Expected, got)
+			}
+		})
+	}
+}
+
+// applyProviderServer answers all the applies, failing if asked to.
+type applyProviderServer struct {
+	tfprotov6.ProviderServer
+
+	fail bool
+}
+
+func (s *applyProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	resp := &tfprotov6.ApplyResourceChangeResponse{}
+	if s.fail {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Client Error",
+		})
+	}
+	return resp, nil
+}
+
+func Test_MetricsProviderServer_Operations(t *testing.T) {
+	reader := newManualReader(t)
+
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+	null, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	known, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "1"),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	apply := func(server tfprotov6.ProviderServer, prior, planned tfprotov6.DynamicValue) {
+		t.Helper()
+		if _, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName:     "ctfdcm_challenge_dynamiciac",
+			PriorState:   &prior,
+			PlannedState: &planned,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server := NewMetricsProviderServer(&applyProviderServer{})
+	apply(server, null, known)
+	apply(server, known, known)
+	apply(server, known, known)
+	apply(server, known, null)
+	apply(NewMetricsProviderServer(&applyProviderServer{fail: true}), null, known)
+
+	ops := counted(t, reader, "terraform.operations", attrTFOperation)
+	if ops["create"] != 2 || ops["update"] != 2 || ops["delete"] != 1 {
+		t.Errorf("expected 2 creates, 2 updates and 1 delete, got %v", ops)
+	}
+	errs := counted(t, reader, "terraform.operations", attrTFError)
+	if errs["true"] != 1 || errs["false"] != 4 {
+		t.Errorf("expected 1 failed operation out of 5, got %v", errs)
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = (*parseOCIRefFunction)(nil)
+
+var ociRefAttributeTypes = map[string]attr.Type{
+	"registry":   types.StringType,
+	"repository": types.StringType,
+	"tag":        types.StringType,
+	"digest":     types.StringType,
+}
+
+func NewParseOCIRefFunction() function.Function {
+	return &parseOCIRefFunction{}
+}
+
+type parseOCIRefFunction struct{}
+
+func (f *parseOCIRefFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_oci_ref"
+}
+
+func (f *parseOCIRefFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse an OCI reference.",
+		MarkdownDescription: "Parses an OCI reference (e.g. a `scenario`) into its `registry`, `repository`, `tag` and `digest`. Missing parts are null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ref",
+				MarkdownDescription: "The OCI reference to parse, e.g. `localhost:5000/some/scenario:v0.1.0`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: ociRefAttributeTypes,
+		},
+	}
+}
+
+func (f *parseOCIRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ref string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &ref))
+	if resp.Error != nil {
+		return
+	}
+
+	registry, repository, tag, digest := parseOCIRef(ref)
+	if repository == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid OCI reference: the repository is empty")
+		return
+	}
+
+	out, diags := types.ObjectValue(ociRefAttributeTypes, map[string]attr.Value{
+		"registry":   nullIfEmpty(registry),
+		"repository": types.StringValue(repository),
+		"tag":        nullIfEmpty(tag),
+		"digest":     nullIfEmpty(digest),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, out))
+}
+
+// parseOCIRef splits an OCI reference in its parts.
+// The registry is only considered present if the first path component looks
+// like a host (i.e. contains a dot or a port, or is localhost), as Docker does.
+func parseOCIRef(ref string) (registry, repository, tag, digest string) {
+	if i := strings.Index(ref, "@"); i != -1 {
+		ref, digest = ref[:i], ref[i+1:]
+	}
+	if i := strings.LastIndex(ref, ":"); i != -1 && !strings.Contains(ref[i+1:], "/") {
+		ref, tag = ref[:i], ref[i+1:]
+	}
+	if first, rest, ok := strings.Cut(ref, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, ref = first, rest
+	}
+	repository = ref
+	return
+}
+
+func nullIfEmpty(str string) types.String {
+	if str == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(str)
+}
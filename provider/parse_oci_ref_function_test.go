@@ -0,0 +1,71 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_ParseOCIRefFunction(t *testing.T) {
+	var tests = map[string]struct {
+		Ref       string
+		Expected  map[string]string
+		ExpectErr bool
+	}{
+		"registry-tag": {
+			Ref: "localhost:5000/some/scenario:v0.1.0",
+			Expected: map[string]string{
+				"registry":   "localhost:5000",
+				"repository": "some/scenario",
+				"tag":        "v0.1.0",
+			},
+		},
+		"no-registry": {
+			Ref: "scenario:v0.1.0",
+			Expected: map[string]string{
+				"repository": "scenario",
+				"tag":        "v0.1.0",
+			},
+		},
+		"digest": {
+			Ref: "ghcr.io/ctfer-io/scenario@sha256:0123456789abcdef",
+			Expected: map[string]string{
+				"registry":   "ghcr.io",
+				"repository": "ctfer-io/scenario",
+				"digest":     "sha256:0123456789abcdef",
+			},
+		},
+		"empty": {
+			Ref:       "",
+			ExpectErr: true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			res, err := runFunction(t, provider.NewParseOCIRefFunction(), types.StringValue(tt.Ref))
+			if tt.ExpectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			attrs := res.(types.Object).Attributes()
+			for _, key := range []string{"registry", "repository", "tag", "digest"} {
+				exp := types.StringNull()
+				if v, ok := tt.Expected[key]; ok {
+					exp = types.StringValue(v)
+				}
+				if !attrs[key].Equal(attr.Value(exp)) {
+					t.Errorf("%s: expected %s, got %s", key, exp, attrs[key])
+				}
+			}
+		})
+	}
+}
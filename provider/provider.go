@@ -6,12 +6,15 @@ import (
 	"os"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
@@ -24,7 +27,13 @@ const (
 	providerTypeName = "ctfdcm"
 )
 
-var _ provider.Provider = (*CTFdCMProvider)(nil)
+var (
+	_ provider.Provider                       = (*CTFdCMProvider)(nil)
+	_ provider.ProviderWithListResources      = (*CTFdCMProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*CTFdCMProvider)(nil)
+	_ provider.ProviderWithActions            = (*CTFdCMProvider)(nil)
+	_ provider.ProviderWithFunctions          = (*CTFdCMProvider)(nil)
+)
 
 type CTFdCMProvider struct {
 	version string
@@ -50,6 +59,14 @@ type CTFdCMProviderModel struct {
 	APIKey   types.String `tfsdk:"api_key"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+
+	Defaults          *ProviderDefaults  `tfsdk:"defaults"`
+	DefaultTags       types.Set          `tfsdk:"default_tags"`
+	DefaultTopics     types.Set          `tfsdk:"default_topics"`
+	ScenarioRegistry  types.String       `tfsdk:"scenario_registry"`
+	ManaBudgetCheck   types.Bool         `tfsdk:"mana_budget_check"`
+	RequirementsCheck types.Bool         `tfsdk:"requirements_check"`
+	Telemetry         *ProviderTelemetry `tfsdk:"telemetry"`
 }
 
 func (p *CTFdCMProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -57,6 +74,22 @@ func (p *CTFdCMProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 	resp.Version = p.version
 }
 
+func (p *CTFdCMProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	p.CTFdProvider.Schema(ctx, req, resp)
+	for k, v := range providerDefaultsAttributes {
+		resp.Schema.Attributes[k] = v
+	}
+	for k, v := range providerManaAttributes {
+		resp.Schema.Attributes[k] = v
+	}
+	for k, v := range providerRequirementsAttributes {
+		resp.Schema.Attributes[k] = v
+	}
+	for k, v := range providerTelemetryAttributes {
+		resp.Schema.Attributes[k] = v
+	}
+}
+
 func (p *CTFdCMProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config CTFdCMProviderModel
 	diags := req.Config.Get(ctx, &config)
@@ -94,11 +127,35 @@ func (p *CTFdCMProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			"The provider cannot create the CTFd API client as there is an unknown password.",
 		)
 	}
+	if config.DefaultTags.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown default tags.",
+			"The provider cannot merge the challenges tags as there are unknown default tags.",
+		)
+	}
+	if config.DefaultTopics.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_topics"),
+			"Unknown default topics.",
+			"The provider cannot merge the challenges topics as there are unknown default topics.",
+		)
+	}
+	if config.ScenarioRegistry.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scenario_registry"),
+			"Unknown scenario registry.",
+			"The provider cannot complete the scenarios references as there is an unknown scenario registry.",
+		)
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Telemetry issues are only warnings, such that it never prevents the provider from working
+	tp := p.tracerProvider(config.Telemetry, &resp.Diagnostics)
+
 	// Extract environment variables values
 	url := os.Getenv("CTFD_URL")
 	apiKey := os.Getenv("CTFD_API_KEY")
@@ -130,13 +187,13 @@ func (p *CTFdCMProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	// Instantiate CTFd API client
-	ctx = tflog.SetField(ctx, "ctfd_url", url)
-	ctx = utils.AddSensitive(ctx, "ctfd_api_key", apiKey)
-	ctx = utils.AddSensitive(ctx, "ctfd_username", username)
-	ctx = utils.AddSensitive(ctx, "ctfd_password", password)
-	tflog.Debug(ctx, "Creating CTFd API client")
+	ctx = logSetField(ctx, "ctfd_url", url)
+	ctx = logAddSensitive(ctx, "ctfd_api_key", apiKey)
+	ctx = logAddSensitive(ctx, "ctfd_username", username)
+	ctx = logAddSensitive(ctx, "ctfd_password", password)
+	logDebug(ctx, "Creating CTFd API client")
 
-	nonce, session, err := GetNonceAndSession(ctx, url, WithTracerProvider(p.tracer))
+	nonce, session, err := GetNonceAndSession(ctx, url, WithTracerProvider(tp))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"CTFd error",
@@ -155,7 +212,7 @@ func (p *CTFdCMProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		if err := client.Login(ctx, &api.LoginParams{
 			Name:     username,
 			Password: password,
-		}, WithTracerProvider(p.tracer)); err != nil {
+		}, WithTracerProvider(tp)); err != nil {
 			resp.Diagnostics.AddError(
 				"CTFd error",
 				fmt.Sprintf("Failed to login: %s", err),
@@ -165,13 +222,33 @@ func (p *CTFdCMProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	d := &Framework{
-		Client: client,
-		Tp:     p.tracer,
+		URL:               url,
+		Client:            client,
+		Tp:                tp,
+		Defaults:          nullProviderDefaults(),
+		ScenarioRegistry:  config.ScenarioRegistry.ValueString(),
+		ManaBudgetCheck:   config.ManaBudgetCheck.ValueBool(),
+		RequirementsCheck: config.RequirementsCheck.ValueBool(),
+		manaBudget:        &manaBudget{},
+		challengeGraph:    &challengeGraph{},
+	}
+	resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &d.DefaultTags, false)...)
+	resp.Diagnostics.Append(config.DefaultTopics.ElementsAs(ctx, &d.DefaultTopics, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Defaults != nil {
+		d.Defaults = *config.Defaults
+		// A zero timeout is no timeout, which CTFd reads back as null
+		d.Defaults.Timeout = readTimeout(types.Int64Null(), utils.ToInt(d.Defaults.Timeout))
 	}
 	resp.DataSourceData = d
 	resp.ResourceData = d
+	resp.ListResourceData = d
+	resp.EphemeralResourceData = d
+	resp.ActionData = d
 
-	tflog.Info(ctx, "Configure CTFd API client", map[string]any{
+	logInfo(ctx, "Configure CTFd API client", map[string]any{
 		"success": true,
 		"login":   up,
 	})
@@ -180,17 +257,63 @@ func (p *CTFdCMProvider) Configure(ctx context.Context, req provider.ConfigureRe
 func (p *CTFdCMProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewChallengeDynamicIaCResource,
+		NewChallengeBundleResource,
 		NewInstanceResource,
+		NewSettingsResource,
+		NewManaBonusResource,
 	}
 }
 
 func (p *CTFdCMProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewChallengeDynamicIaCDataSource,
+		NewManaDataSource,
+	}
+}
+
+func (p *CTFdCMProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewInstanceEphemeralResource,
+		NewTokenEphemeralResource,
+	}
+}
+
+func (p *CTFdCMProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewChallengeDynamicIaCListResource,
+		NewInstanceListResource,
+	}
+}
+
+func (p *CTFdCMProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewInstanceRestartAction,
+		NewInstanceRenewAction,
+		NewInstanceDestroyAction,
+		NewChallengePrewarmAction,
+	}
+}
+
+func (p *CTFdCMProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseOCIRefFunction,
+		NewUntilInFunction,
+		NewConnectionInfoFunction,
 	}
 }
 
 type Framework struct {
+	URL    string
 	Client *Client
 	Tp     trace.TracerProvider
+
+	Defaults          ProviderDefaults
+	DefaultTags       []string
+	DefaultTopics     []string
+	ScenarioRegistry  string
+	ManaBudgetCheck   bool
+	RequirementsCheck bool
+
+	manaBudget     *manaBudget
+	challengeGraph *challengeGraph
 }
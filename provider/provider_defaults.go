@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProviderDefaults are the provider-level defaults of the challenges attributes,
+// applied when they are not set on the challenge itself.
+type ProviderDefaults struct {
+	Timeout       types.Int64 `tfsdk:"timeout"`
+	ManaCost      types.Int64 `tfsdk:"mana_cost"`
+	DestroyOnFlag types.Bool  `tfsdk:"destroy_on_flag"`
+}
+
+var providerDefaultsAttributes = map[string]schema.Attribute{
+	"defaults": schema.SingleNestedAttribute{
+		MarkdownDescription: "Default values of the `ctfdcm_challenge_dynamiciac` attributes, applied when they are not set on the challenge.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "The default timeout (in seconds) after which the instances will be janitored. A zero timeout is considered as no timeout.",
+				Optional:            true,
+			},
+			"mana_cost": schema.Int64Attribute{
+				MarkdownDescription: "The default cost (in mana) of the challenges once an instance is deployed.",
+				Optional:            true,
+			},
+			"destroy_on_flag": schema.BoolAttribute{
+				MarkdownDescription: "Whether to destroy the instances once flagged, by default.",
+				Optional:            true,
+			},
+		},
+	},
+	"default_tags": schema.SetAttribute{
+		MarkdownDescription: "Tags merged into the ones of every `ctfdcm_challenge_dynamiciac`, e.g. the event edition. The effective tags are exposed in `tags_all`.",
+		ElementType:         types.StringType,
+		Optional:            true,
+	},
+	"default_topics": schema.SetAttribute{
+		MarkdownDescription: "Topics merged into the ones of every `ctfdcm_challenge_dynamiciac`, e.g. the topics taxonomy. The effective topics are exposed in `topics_all`.",
+		ElementType:         types.StringType,
+		Optional:            true,
+	},
+	"scenario_registry": schema.StringAttribute{
+		MarkdownDescription: "The OCI registry (and optionally repository) prefix of the scenarios (e.g. `registry.my-ctf.lan/scenarios`). When set, `scenario` could be written as a short `name:tag`.",
+		Optional:            true,
+	},
+}
+
+// nullProviderDefaults returns the provider-level defaults when none is configured.
+func nullProviderDefaults() ProviderDefaults {
+	return ProviderDefaults{
+		Timeout:       types.Int64Null(),
+		ManaCost:      types.Int64Null(),
+		DestroyOnFlag: types.BoolNull(),
+	}
+}
+
+// defaults returns the provider-level defaults, or none if the provider
+// has not been configured yet.
+func (fm *Framework) defaults() ProviderDefaults {
+	if fm == nil {
+		return nullProviderDefaults()
+	}
+	return fm.Defaults
+}
+
+// defaultTags returns the provider-level tags, if any.
+func (fm *Framework) defaultTags() []string {
+	if fm == nil {
+		return nil
+	}
+	return fm.DefaultTags
+}
+
+// defaultTopics returns the provider-level topics, if any.
+func (fm *Framework) defaultTopics() []string {
+	if fm == nil {
+		return nil
+	}
+	return fm.DefaultTopics
+}
+
+// mergeDefaults returns the values along with the defaults they do not contain yet.
+func mergeDefaults(values []types.String, defaults []string) []types.String {
+	out := slices.Clone(values)
+	for _, def := range defaults {
+		if !slices.ContainsFunc(out, func(v types.String) bool { return v.ValueString() == def }) {
+			out = append(out, types.StringValue(def))
+		}
+	}
+	return out
+}
+
+// withoutDefaults returns the values without the defaults, unless they were
+// explicitly set in prior, such that merged entries do not produce diffs.
+func withoutDefaults(values, prior []types.String, defaults []string) []types.String {
+	out := make([]types.String, 0, len(values))
+	for _, v := range values {
+		if slices.Contains(defaults, v.ValueString()) && !slices.ContainsFunc(prior, func(p types.String) bool { return p.Equal(v) }) {
+			continue
+		}
+		out = append(out, v)
+	}
+	if prior == nil && len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// readTimeout returns a timeout as read from CTFd. A zero timeout is equivalent to no
+// timeout, so it is kept null to round-trip with the configuration, unless it was
+// explicitly set to zero in prior.
+func readTimeout(prior types.Int64, got *int) types.Int64 {
+	explicitZero := !prior.IsNull() && prior.ValueInt64() == 0
+	if got == nil || (*got == 0 && !explicitZero) {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*got))
+}
+
+// stringSetValue returns the set of the string values.
+func stringSetValue(values []types.String) types.Set {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, v)
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+// scenarioRef returns the full OCI reference of a scenario, prefixing it with
+// the provider scenario registry if it does not define its own.
+func (fm *Framework) scenarioRef(scenario string) string {
+	if fm == nil || fm.ScenarioRegistry == "" || scenario == "" {
+		return scenario
+	}
+	if registry, _, _, _ := parseOCIRef(scenario); registry != "" {
+		return scenario
+	}
+	return strings.TrimSuffix(fm.ScenarioRegistry, "/") + "/" + scenario
+}
+
+// applyDefaults sets the planned attributes left unset in the configuration to the
+// provider defaults, if any, and merges the provider default tags and topics.
+func (fm *Framework) applyDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defs := fm.defaults()
+
+	var dof types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("destroy_on_flag"), &dof)...)
+	if dof.IsNull() && !defs.DestroyOnFlag.IsNull() && !defs.DestroyOnFlag.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("destroy_on_flag"), defs.DestroyOnFlag)...)
+	}
+
+	for _, v := range []struct {
+		path path.Path
+		def  types.Int64
+	}{
+		{path.Root("mana_cost"), defs.ManaCost},
+		{path.Root("timeout"), defs.Timeout},
+	} {
+		var config, plan types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.path, &config)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, v.path, &plan)...)
+		if resp.Diagnostics.HasError() || !config.IsNull() {
+			continue
+		}
+		if !v.def.IsNull() && !v.def.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, v.path, v.def)...)
+			continue
+		}
+		// Without a static default, do not leave it unknown as it won't be computed
+		if plan.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, v.path, types.Int64Null())...)
+		}
+	}
+
+	// Merge the provider default tags and topics, as create and update do
+	for _, v := range []struct {
+		from, to path.Path
+		defaults []string
+	}{
+		{path.Root("tags"), path.Root("tags_all"), fm.defaultTags()},
+		{path.Root("topics"), path.Root("topics_all"), fm.defaultTopics()},
+	} {
+		var values types.Set
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, v.from, &values)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if values.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, v.to, types.SetUnknown(types.StringType))...)
+			continue
+		}
+
+		var strs []types.String
+		resp.Diagnostics.Append(values.ElementsAs(ctx, &strs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, v.to, stringSetValue(mergeDefaults(strs, v.defaults)))...)
+	}
+}
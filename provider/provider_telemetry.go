@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	telemetryProtocolGRPC = "grpc"
+	telemetryProtocolHTTP = "http/protobuf"
+)
+
+// ProviderTelemetry configures the export of the provider traces, rather than the
+// OTEL_* environment variables the provider started with.
+type ProviderTelemetry struct {
+	Enabled            types.Bool    `tfsdk:"enabled"`
+	Endpoint           types.String  `tfsdk:"endpoint"`
+	Protocol           types.String  `tfsdk:"protocol"`
+	Headers            types.Map     `tfsdk:"headers"`
+	SamplingRatio      types.Float64 `tfsdk:"sampling_ratio"`
+	ResourceAttributes types.Map     `tfsdk:"resource_attributes"`
+}
+
+var providerTelemetryAttributes = map[string]schema.Attribute{
+	"telemetry": schema.SingleNestedAttribute{
+		MarkdownDescription: "Configuration of the provider traces export through OTLP, rather than the `OTEL_*` environment variables. Telemetry issues are reported as warnings, and never prevent the provider from working.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether to export the provider traces. Defaults to `true`.",
+				Optional:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The URL of the OTLP endpoint to export the traces to (e.g. `http://collector:4318`). Defaults to the `OTEL_EXPORTER_OTLP_ENDPOINT` environment variable.",
+				Optional:            true,
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "The OTLP protocol, either `grpc` or `http/protobuf`. Defaults to the `OTEL_EXPORTER_OTLP_PROTOCOL` environment variable, else `http/protobuf`.",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "The headers to send the traces with, e.g. for authentication.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"sampling_ratio": schema.Float64Attribute{
+				MarkdownDescription: "The ratio of the traces to sample, between 0 and 1. The sampling decision of the parent trace is respected, if any. Defaults to 1.",
+				Optional:            true,
+			},
+			"resource_attributes": schema.MapAttribute{
+				MarkdownDescription: "Additional attributes of the OpenTelemetry resource, e.g. the deployment environment.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	},
+}
+
+// tracerProvider returns the tracer provider configured by the telemetry block, or
+// the one the provider started with. Invalid configurations are reported as warnings
+// and fall back to the latter.
+func (p *CTFdCMProvider) tracerProvider(tel *ProviderTelemetry, diags *diag.Diagnostics) trace.TracerProvider {
+	if tel == nil {
+		return p.tracer
+	}
+
+	valid := true
+	for _, attr := range []struct {
+		name    string
+		unknown bool
+	}{
+		{"enabled", tel.Enabled.IsUnknown()},
+		{"endpoint", tel.Endpoint.IsUnknown()},
+		{"protocol", tel.Protocol.IsUnknown()},
+		{"headers", tel.Headers.IsUnknown()},
+		{"sampling_ratio", tel.SamplingRatio.IsUnknown()},
+		{"resource_attributes", tel.ResourceAttributes.IsUnknown()},
+	} {
+		if attr.unknown {
+			diags.AddAttributeWarning(
+				path.Root("telemetry").AtName(attr.name),
+				"Unknown telemetry configuration.",
+				"The provider cannot configure its telemetry as there is an unknown value, it falls back to the environment variables.",
+			)
+			valid = false
+		}
+	}
+	if !valid {
+		return p.tracer
+	}
+	if !tel.Enabled.IsNull() && !tel.Enabled.ValueBool() {
+		return noop.NewTracerProvider()
+	}
+
+	if proto := tel.Protocol.ValueString(); proto != "" && proto != telemetryProtocolGRPC && proto != telemetryProtocolHTTP {
+		diags.AddAttributeWarning(
+			path.Root("telemetry").AtName("protocol"),
+			"Invalid telemetry protocol.",
+			fmt.Sprintf("The protocol must be either %s or %s, got %s. The provider falls back to the environment variables.", telemetryProtocolGRPC, telemetryProtocolHTTP, proto),
+		)
+		valid = false
+	}
+	if ratio := tel.SamplingRatio.ValueFloat64(); ratio < 0 || ratio > 1 {
+		diags.AddAttributeWarning(
+			path.Root("telemetry").AtName("sampling_ratio"),
+			"Invalid telemetry sampling ratio.",
+			fmt.Sprintf("The sampling ratio must be between 0 and 1, got %f. The provider falls back to the environment variables.", ratio),
+		)
+		valid = false
+	}
+	if !valid {
+		return p.tracer
+	}
+
+	return &lazyTracerProvider{
+		version:   p.version,
+		telemetry: *tel,
+		fallback:  p.tracer,
+	}
+}
+
+// lazyTracerProvider builds the tracer provider configured by the telemetry block
+// on first use, such that configuring the provider never waits on the exporter.
+// If it could not be built, the spans go to the fallback one.
+type lazyTracerProvider struct {
+	embedded.TracerProvider
+
+	version   string
+	telemetry ProviderTelemetry
+	fallback  trace.TracerProvider
+
+	once sync.Once
+	tp   trace.TracerProvider
+}
+
+func (l *lazyTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	l.once.Do(func() {
+		tp, err := newTelemetryTracerProvider(context.Background(), l.version, l.telemetry)
+		if err != nil {
+			log.Printf("Error setting up the telemetry, falling back to the environment variables: %v", err)
+			l.tp = l.fallback
+			return
+		}
+		l.tp = tp
+	})
+	return l.tp.Tracer(name, opts...)
+}
+
+func newTelemetryTracerProvider(ctx context.Context, version string, tel ProviderTelemetry) (trace.TracerProvider, error) {
+	headers := map[string]string{}
+	for k, v := range tel.Headers.Elements() {
+		headers[k] = v.(types.String).ValueString()
+	}
+
+	proto := tel.Protocol.ValueString()
+	if proto == "" {
+		proto = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	var exp sdktrace.SpanExporter
+	var err error
+	switch proto {
+	case telemetryProtocolGRPC:
+		opts := []otlptracegrpc.Option{}
+		if !tel.Endpoint.IsNull() {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(tel.Endpoint.ValueString()))
+		}
+		if len(headers) != 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(headers))
+		}
+		exp, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		opts := []otlptracehttp.Option{}
+		if !tel.Endpoint.IsNull() {
+			opts = append(opts, otlptracehttp.WithEndpointURL(tel.Endpoint.ValueString()))
+		}
+		if len(headers) != 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(headers))
+		}
+		exp, err = otlptracehttp.New(ctx, opts...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating span exporter: %w", err)
+	}
+
+	attrs := []attribute.KeyValue{}
+	for k, v := range tel.ResourceAttributes.Elements() {
+		attrs = append(attrs, attribute.String(k, v.(types.String).ValueString()))
+	}
+	r, err := otelResource(version, attrs...)
+	if err != nil {
+		_ = exp.Shutdown(ctx)
+		return nil, fmt.Errorf("creating resource: %w", err)
+	}
+
+	ratio := 1.
+	if !tel.SamplingRatio.IsNull() {
+		ratio = tel.SamplingRatio.ValueFloat64()
+	}
+	tp := sdktrace.NewTracerProvider(
+		// As for the environment configured one, spans are exported as soon as they end
+		sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(exp)),
+		sdktrace.WithResource(r),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	registerShutdown(tp.Shutdown)
+	return withEnvParent(ctx, tp), nil
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// newProviderTelemetry returns a valid telemetry configuration, as decoded from
+// a configuration only setting the endpoint.
+func newProviderTelemetry() *ProviderTelemetry {
+	return &ProviderTelemetry{
+		Enabled:            types.BoolNull(),
+		Endpoint:           types.StringValue("http://localhost:4318"),
+		Protocol:           types.StringNull(),
+		Headers:            types.MapNull(types.StringType),
+		SamplingRatio:      types.Float64Null(),
+		ResourceAttributes: types.MapNull(types.StringType),
+	}
+}
+
+func Test_CTFdCMProvider_TracerProvider(t *testing.T) {
+	fallback := sdktrace.NewTracerProvider()
+	t.Cleanup(func() {
+		_ = fallback.Shutdown(context.Background())
+	})
+	p := &CTFdCMProvider{
+		version: "test",
+		tracer:  fallback,
+	}
+
+	var tests = map[string]struct {
+		Telemetry func(tel *ProviderTelemetry) *ProviderTelemetry
+		Warning   string
+		Expected  string
+	}{
+		"unset": {
+			Telemetry: func(tel *ProviderTelemetry) *ProviderTelemetry { return nil },
+			Expected:  "fallback",
+		},
+		"disabled": {
+			Telemetry: func(tel *ProviderTelemetry) *ProviderTelemetry {
+				tel.Enabled = types.BoolValue(false)
+				return tel
+			},
+			Expected: "noop",
+		},
+		"unknown": {
+			Telemetry: func(tel *ProviderTelemetry) *ProviderTelemetry {
+				tel.Endpoint = types.StringUnknown()
+				return tel
+			},
+			Warning:  "Unknown telemetry configuration.",
+			Expected: "fallback",
+		},
+		"invalid-protocol": {
+			Telemetry: func(tel *ProviderTelemetry) *ProviderTelemetry {
+				tel.Protocol = types.StringValue("carrier-pigeon")
+				return tel
+			},
+			Warning:  "Invalid telemetry protocol.",
+			Expected: "fallback",
+		},
+		"invalid-sampling-ratio": {
+			Telemetry: func(tel *ProviderTelemetry) *ProviderTelemetry {
+				tel.SamplingRatio = types.Float64Value(2)
+				return tel
+			},
+			Warning:  "Invalid telemetry sampling ratio.",
+			Expected: "fallback",
+		},
+		"valid": {
+			Telemetry: func(tel *ProviderTelemetry) *ProviderTelemetry {
+				tel.Protocol = types.StringValue(telemetryProtocolGRPC)
+				tel.SamplingRatio = types.Float64Value(0.5)
+				tel.ResourceAttributes = types.MapValueMust(types.StringType, map[string]attr.Value{
+					"deployment.environment.name": types.StringValue("test"),
+				})
+				return tel
+			},
+			Expected: "lazy",
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			tp := p.tracerProvider(tt.Telemetry(newProviderTelemetry()), &diags)
+
+			if diags.HasError() {
+				t.Fatalf("expected no error, got %v", diags)
+			}
+			switch {
+			case tt.Warning == "" && diags.WarningsCount() != 0:
+				t.Errorf("expected no warning, got %v", diags)
+			case tt.Warning != "" && (diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != tt.Warning):
+				t.Errorf("expected a %q warning, got %v", tt.Warning, diags)
+			}
+
+			got := "other"
+			switch tp := tp.(type) {
+			case *sdktrace.TracerProvider:
+				if tp == fallback {
+					got = "fallback"
+				}
+			case noop.TracerProvider:
+				got = "noop"
+			case *lazyTracerProvider:
+				got = "lazy"
+			}
+			if got != tt.Expected {
+				t.Errorf("expected the %s tracer provider, got %T (%s)", tt.Expected, tp, got)
+			}
+		})
+	}
+}
+
+func Test_LazyTracerProvider(t *testing.T) {
+	fallback := sdktrace.NewTracerProvider()
+	t.Cleanup(func() {
+		_ = fallback.Shutdown(context.Background())
+	})
+
+	diags := diag.Diagnostics{}
+	p := &CTFdCMProvider{
+		version: "test",
+		tracer:  fallback,
+	}
+	l, ok := p.tracerProvider(newProviderTelemetry(), &diags).(*lazyTracerProvider)
+	if !ok || diags.HasError() {
+		t.Fatalf("expected a lazy tracer provider, got %v", diags)
+	}
+
+	// Nothing is built until a tracer is asked for
+	if l.tp != nil {
+		t.Fatalf("expected the tracer provider not to be built yet")
+	}
+	_ = l.Tracer(serviceName)
+	if l.tp == nil || l.tp == fallback {
+		t.Fatalf("expected the configured tracer provider to be built, got %T", l.tp)
+	}
+
+	// It is only built once
+	tp := l.tp
+	_ = l.Tracer(serviceName)
+	if l.tp != tp {
+		t.Errorf("expected the tracer provider to be built once")
+	}
+}
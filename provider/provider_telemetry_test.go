@@ -0,0 +1,49 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Provider_Telemetry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Disabled telemetry does not change the provider behavior
+			{
+				Config: `
+provider "ctfdcm" {
+	telemetry = {
+		enabled = false
+	}
+}
+
+data "ctfdcm_challenges_dynamiciac" "all" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ctfdcm_challenges_dynamiciac.all", "id"),
+				),
+			},
+			// An invalid configuration only warns, so the provider must still work
+			{
+				Config: `
+provider "ctfdcm" {
+	telemetry = {
+		protocol       = "carrier-pigeon"
+		sampling_ratio = 2
+		resource_attributes = {
+			"deployment.environment.name" = "test"
+		}
+	}
+}
+
+data "ctfdcm_challenges_dynamiciac" "all" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ctfdcm_challenges_dynamiciac.all", "id"),
+				),
+			},
+		},
+	})
+}
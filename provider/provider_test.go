@@ -1,7 +1,18 @@
 package provider_test
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
 
 const (
@@ -18,3 +29,81 @@ var (
 	// reattach.
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){}
 )
+
+// testAccProtoV6ProviderFactoriesWithEcho are the acceptance testing provider
+// factories along with the echo provider, to test ephemeral resources.
+func testAccProtoV6ProviderFactoriesWithEcho() map[string]func() (tfprotov6.ProviderServer, error) {
+	factories := map[string]func() (tfprotov6.ProviderServer, error){
+		"echo": echoprovider.NewProviderServer(),
+	}
+	for name, factory := range testAccProtoV6ProviderFactories {
+		factories[name] = factory
+	}
+	return factories
+}
+
+// upgradeState runs the state upgrader of the resource from the given schema version,
+// on the JSON state fixture read from testdata.
+func upgradeState(t *testing.T, r resource.Resource, version int64, fixture string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatalf("reading fixture %s: %s", fixture, err)
+	}
+
+	upgrader, ok := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader from version %d", version)
+	}
+	prior, err := (&tfprotov6.RawState{JSON: raw}).Unmarshal(upgrader.PriorSchema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("decoding fixture %s: %s", fixture, err)
+	}
+
+	sch := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, sch)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw:    prior,
+		},
+	}
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: sch.Schema,
+			Raw:    tftypes.NewValue(sch.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrading state from version %d: %v", version, resp.Diagnostics)
+	}
+	return resp.State
+}
+
+// runFunction runs the provider function with the given arguments, and returns
+// its result.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+
+	def := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, def)
+
+	typ := def.Definition.Return.GetType()
+	unknown, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(typ.TerraformType(ctx), tftypes.UnknownValue))
+	if err != nil {
+		t.Fatalf("building result: %s", err)
+	}
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(unknown),
+	}
+	f.Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData(args),
+	}, resp)
+	return resp.Result.Value(), resp.Error
+}
@@ -18,6 +18,7 @@ func main() {
 
 		// 3. Export outputs
 		ctx.Export("connection_info", pulumi.Sprintf("curl -v https://%s.brefctf.ctfer.io", config["identity"]))
+		ctx.Export("flag", pulumi.Sprintf("BREFCTF{%s}", config["identity"]))
 		return nil
 	})
 }
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfdcm "github.com/ctfer-io/go-ctfdcm/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+var (
+	_ resource.Resource                = (*settingsResource)(nil)
+	_ resource.ResourceWithConfigure   = (*settingsResource)(nil)
+	_ resource.ResourceWithImportState = (*settingsResource)(nil)
+)
+
+const (
+	// settingsID is the identifier of the settings, as there is only one per CTFd.
+	settingsID = "settings"
+)
+
+func NewSettingsResource() resource.Resource {
+	return &settingsResource{}
+}
+
+type settingsResource struct {
+	fm *Framework
+}
+
+type SettingsResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ChallManagerAPIURL types.String `tfsdk:"chall_manager_api_url"`
+	ManaTotal          types.Int64  `tfsdk:"mana_total"`
+	InstanceTimeout    types.Int64  `tfsdk:"instance_timeout"`
+}
+
+func (r *settingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (r *settingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The global configuration of the CTFd-Chall-Manager plugin. There is only one per CTFd, so it should be declared once.\n\nDestroying it only removes it from the Terraform state, the configuration is left as is in CTFd.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the settings, always `settings`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chall_manager_api_url": schema.StringAttribute{
+				MarkdownDescription: "The Chall-Manager API URL to reach it from CTFd (e.g. `http://chall-manager:8080`).",
+				Required:            true,
+			},
+			"mana_total": schema.Int64Attribute{
+				MarkdownDescription: "The total mana each source could spend on instances at once. A zero mana total disables the mana mechanism.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Int64(int64default.StaticInt64(0)),
+			},
+			"instance_timeout": schema.Int64Attribute{
+				MarkdownDescription: "The default timeout (in seconds) after which instances are janitored, when their challenge has none. A zero timeout is considered as no timeout, and the timeout is reset to zero once unset.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *settingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *settingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data SettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Settings always exist, so take ownership of them
+	data.patch(ctx, r.fm, &resp.Diagnostics)
+	data.ID = types.StringValue(settingsID)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *settingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data SettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, _, err := r.fm.Client.GetAdminSettings(ctx, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read settings, got error: %s", err),
+		)
+		return
+	}
+	data.ID = types.StringValue(settingsID)
+	data.ChallManagerAPIURL = types.StringValue(settings.ChallManagerAPIURL)
+	data.ManaTotal = types.Int64Value(int64(settings.ManaTotal))
+	data.InstanceTimeout = readTimeout(data.InstanceTimeout, settings.InstanceTimeout)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *settingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data SettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.patch(ctx, r.fm, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *settingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Settings could not be deleted, they are only removed from the state
+	resp.Diagnostics.AddWarning(
+		"Settings Left Unchanged",
+		"The CTFd-Chall-Manager plugin settings could not be deleted, they have only been removed from the Terraform state.",
+	)
+}
+
+func (r *settingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Whatever the identifier, there is only one settings
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), settingsID)...)
+
+	// Automatically call r.Read
+}
+
+// patch applies the settings to CTFd.
+func (data *SettingsResourceModel) patch(ctx context.Context, fm *Framework, diags *diag.Diagnostics) {
+	if _, _, err := fm.Client.PatchAdminSettings(ctx, &ctfdcm.PatchAdminSettingsParams{
+		ChallManagerAPIURL: data.ChallManagerAPIURL.ValueString(),
+		ManaTotal:          int(data.ManaTotal.ValueInt64()),
+		// Without timeout, reset the one previously set
+		InstanceTimeout: utils.Ptr(int(data.InstanceTimeout.ValueInt64())),
+	}, WithTracerProvider(fm.Tp)); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update settings, got error: %s", err),
+		)
+	}
+}
@@ -0,0 +1,116 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAcc_Settings_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, with the CI configuration such that other tests are not impacted
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_settings.settings", "id", "settings"),
+					resource.TestCheckNoResourceAttr("ctfdcm_settings.settings", "instance_timeout"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfdcm_settings.settings",
+				ImportState:       true,
+				ImportStateId:     "settings",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+	instance_timeout      = 3600
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_settings.settings", "instance_timeout", "3600"),
+				),
+			},
+			// Removing the timeout must reset it, and not produce changes afterwards
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ctfdcm_settings.settings", "instance_timeout"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// An explicit zero timeout must not produce changes
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+	instance_timeout      = 0
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfdcm_settings.settings", "instance_timeout", "0"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+	instance_timeout      = 0
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Restore the CI configuration, drift would be detected otherwise
+			{
+				Config: providerConfig + `
+resource "ctfdcm_settings" "settings" {
+	chall_manager_api_url = "http://chall-manager:8080"
+	mana_total            = 10
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ctfdcm_settings.settings", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
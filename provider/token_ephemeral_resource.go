@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ctfd "github.com/ctfer-io/go-ctfd/api"
+	tfctfd "github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*tokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*tokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*tokenEphemeralResource)(nil)
+)
+
+const (
+	// privateKeyToken is the private data key holding the token to revoke.
+	privateKeyToken = "token"
+)
+
+func NewTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &tokenEphemeralResource{}
+}
+
+type tokenEphemeralResource struct {
+	fm *Framework
+}
+
+type TokenEphemeralResourceModel struct {
+	URL         types.String `tfsdk:"url"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Description types.String `tfsdk:"description"`
+	ID          types.String `tfsdk:"id"`
+	Token       types.String `tfsdk:"token"`
+	Expiration  types.String `tfsdk:"expiration"`
+}
+
+// tokenPrivateData is the token reference kept between Open and Close.
+type tokenPrivateData struct {
+	URL   string `json:"url"`
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+func (r *tokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (r *tokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A CTFd API token, issued after logging in with the given credentials. It is never persisted in the state, and revoked once Terraform is done with it.\n\nThis is convenient to hand a short-lived session to other providers or data sources without storing the credentials.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "CTFd base URL (e.g. `https://my-ctf.lan`). Defaults to the provider one.",
+				Optional:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The administrator or service account username to login with.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The administrator or service account password to login with.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the token, displayed in CTFd.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the token.",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The API token, to use as `Authorization: Token <token>`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "The expiration date of the token. It is revoked earlier, when Terraform is done with it.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *tokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	fm, ok := req.ProviderData.(*Framework)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected %T, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfdcm", (*Framework)(nil), req.ProviderData),
+		)
+		return
+	}
+
+	r.fm = fm
+}
+
+func (r *tokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	var data TokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := r.fm.URL
+	if !data.URL.IsNull() {
+		url = data.URL.ValueString()
+	}
+
+	ctx = logSetField(ctx, "ctfd_url", url)
+	ctx = logAddSensitive(ctx, "ctfd_username", data.Username.ValueString())
+	ctx = logAddSensitive(ctx, "ctfd_password", data.Password.ValueString())
+	logDebug(ctx, "Logging in to issue a CTFd API token")
+
+	nonce, session, err := GetNonceAndSession(ctx, url, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"CTFd error",
+			fmt.Sprintf("Failed to fetch nonce and session: %s", err),
+		)
+		return
+	}
+
+	// XXX due to the CTFd ratelimiter on rare endpoint
+	if _, ok := os.LookupEnv("TF_ACC"); ok {
+		time.Sleep(5 * time.Second)
+	}
+
+	client := NewClient(url, nonce, session, "")
+	if err := client.Login(ctx, &ctfd.LoginParams{
+		Name:     data.Username.ValueString(),
+		Password: data.Password.ValueString(),
+	}, WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"CTFd error",
+			fmt.Sprintf("Failed to login: %s", err),
+		)
+		return
+	}
+
+	// CTFd tokens expire on a daily basis, so ask for the next day
+	token, _, err := client.PostTokens(ctx, &ctfd.PostTokensParams{
+		Expiration:  time.Now().Add(24 * time.Hour).Format(time.DateOnly),
+		Description: data.Description.ValueStringPointer(),
+	}, WithTracerProvider(r.fm.Tp))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create token, got error: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(token.ID))
+	data.Token = types.StringValue(token.Value)
+	data.Expiration = types.StringValue(token.Expiration)
+
+	b, _ := json.Marshal(tokenPrivateData{
+		URL:   url,
+		ID:    data.ID.ValueString(),
+		Token: token.Value,
+	})
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyToken, b)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *tokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx, span := tfctfd.StartTFSpan(ctx, r.fm.Tp.Tracer(serviceName), r)
+	defer span.End()
+
+	pd := &tokenPrivateData{}
+	getPrivateData(ctx, req.Private, privateKeyToken, pd, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoke the token with itself, as the session is not kept
+	client := NewClient(pd.URL, "", "", pd.Token)
+	if _, err := client.DeleteToken(ctx, pd.ID, WithTracerProvider(r.fm.Tp)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to revoke token %s, got error: %s", pd.ID, err),
+		)
+		return
+	}
+}
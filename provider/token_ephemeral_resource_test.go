@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_TokenEphemeral_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "ctfdcm_token" "ci" {
+	username    = var.username
+	password    = var.password
+	description = "CI token"
+}
+
+provider "echo" {
+	data = ephemeral.ctfdcm_token.ci
+}
+
+resource "echo" "ci" {}
+
+variable "username" {
+  type      = string
+  sensitive = true
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+`,
+				ConfigVariables: config.Variables{
+					"username": config.StringVariable(os.Getenv("CTFD_ADMIN_USERNAME")),
+					"password": config.StringVariable(os.Getenv("CTFD_ADMIN_PASSWORD")),
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.ci", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.ci", tfjsonpath.New("data").AtMapKey("expiration"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
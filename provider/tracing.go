@@ -2,10 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
 
 	"go.opentelemetry.io/contrib/exporters/autoexport"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
@@ -20,29 +31,52 @@ const (
 type OTelSetup struct {
 	Shutdown       func(context.Context) error
 	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	LoggerProvider log.LoggerProvider
 }
 
+// telemetryShutdowns are the cleanup functions of the tracer providers configured
+// by the provider telemetry blocks, called along the OTel SDK ones.
+var (
+	telemetryMu        sync.Mutex
+	telemetryShutdowns []func(context.Context) error
+)
+
+func registerShutdown(fn func(context.Context) error) {
+	telemetryMu.Lock()
+	defer telemetryMu.Unlock()
+
+	telemetryShutdowns = append(telemetryShutdowns, fn)
+}
+
+// SetupOTelSDK configures the OTel SDK from the OTEL_* environment variables.
+// Each signal is set up on its own: if one fails, the error is returned while the
+// others remain usable, and the failing one falls back to the global no-op provider,
+// such that telemetry never prevents the provider from serving.
 func SetupOTelSDK(ctx context.Context, version string) (out OTelSetup, err error) {
+	out.TracerProvider = otel.GetTracerProvider()
+	out.MeterProvider = otel.GetMeterProvider()
+	out.LoggerProvider = global.GetLoggerProvider()
+
 	var shutdownFuncs []func(context.Context) error
 
-	// shutdown calls cleanup functions registered via shutdownFuncs.
+	// shutdown calls cleanup functions registered via shutdownFuncs, then the
+	// telemetry ones.
 	// The errors from the calls are joined.
 	// Each registered cleanup will be invoked once.
-
 	out.Shutdown = func(ctx context.Context) error {
+		telemetryMu.Lock()
+		fns := slices.Concat(shutdownFuncs, telemetryShutdowns)
+		shutdownFuncs, telemetryShutdowns = nil, nil
+		telemetryMu.Unlock()
+
 		var err error
-		for _, fn := range shutdownFuncs {
+		for _, fn := range fns {
 			err = multierr.Append(err, fn(ctx))
 		}
-		shutdownFuncs = nil
 		return err
 	}
 
-	// handleErr calls shutdown for cleanup and makes sure that all errors are returned
-	handleErr := func(inErr error) {
-		err = multierr.Append(inErr, out.Shutdown(ctx))
-	}
-
 	// Set up propagator
 	prop := propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
@@ -50,33 +84,125 @@ func SetupOTelSDK(ctx context.Context, version string) (out OTelSetup, err error
 	)
 	otel.SetTextMapPropagator(prop)
 
-	// Ensure default SDK resources and the required service name are set
-	r, err := resource.Merge(
+	r, nerr := otelResource(version)
+	if nerr != nil {
+		// The resource could be partial, but remains usable
+		err = multierr.Append(err, fmt.Errorf("creating resource: %w", nerr))
+		if r == nil {
+			r = resource.Default()
+		}
+	}
+
+	// Then create the span exporter
+	if exp, nerr := autoexport.NewSpanExporter(ctx); nerr != nil {
+		err = multierr.Append(err, fmt.Errorf("creating span exporter: %w", nerr))
+	} else {
+		tp := sdktrace.NewTracerProvider(
+			// We need to have the burden of a simple span processor as the process might be short-lived
+			// because a batch processor can not give enough time to export data...
+			sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(exp)),
+			sdktrace.WithResource(r),
+		)
+		shutdownFuncs = append(shutdownFuncs, tp.Shutdown)
+
+		// Continue the trace of the caller (e.g. a CI pipeline), if any
+		out.TracerProvider = withEnvParent(ctx, tp)
+	}
+
+	// Then create the metric reader, exported at shutdown if not before
+	if reader, nerr := autoexport.NewMetricReader(ctx); nerr != nil {
+		err = multierr.Append(err, fmt.Errorf("creating metric reader: %w", nerr))
+	} else {
+		mp := sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(reader),
+			sdkmetric.WithResource(r),
+		)
+		shutdownFuncs = append(shutdownFuncs, mp.Shutdown)
+		otel.SetMeterProvider(mp)
+		out.MeterProvider = mp
+	}
+
+	// Then create the log exporter, if logs are to be exported along the traces,
+	// as the provider logs already go to Terraform
+	if exporter, ok := os.LookupEnv("OTEL_LOGS_EXPORTER"); ok && exporter != "none" {
+		if lexp, nerr := autoexport.NewLogExporter(ctx); nerr != nil {
+			err = multierr.Append(err, fmt.Errorf("creating log exporter: %w", nerr))
+		} else {
+			lp := sdklog.NewLoggerProvider(
+				// As for spans, a batch processor might not have enough time to export data
+				sdklog.WithProcessor(sdklog.NewSimpleProcessor(lexp)),
+				sdklog.WithResource(r),
+			)
+			shutdownFuncs = append(shutdownFuncs, lp.Shutdown)
+			global.SetLoggerProvider(lp)
+			out.LoggerProvider = lp
+		}
+	}
+
+	return
+}
+
+// otelResource ensures default SDK resources and the required service name are set,
+// along with the additional attributes.
+func otelResource(version string, attrs ...attribute.KeyValue) (*resource.Resource, error) {
+	return resource.Merge(
 		resource.Environment(),
 		resource.NewWithAttributes(
 			semconv.SchemaURL,
-			semconv.ServiceName(serviceName),
-			semconv.ServiceVersion(version),
+			append([]attribute.KeyValue{
+				semconv.ServiceName(serviceName),
+				semconv.ServiceVersion(version),
+			}, attrs...)...,
 		),
 	)
-	if err != nil {
-		return
+}
+
+// withEnvParent makes the tracer provider continue the trace of the caller, if any.
+func withEnvParent(ctx context.Context, tp trace.TracerProvider) trace.TracerProvider {
+	if parent := envParentContext(ctx); trace.SpanContextFromContext(parent).IsValid() {
+		return &parentTracerProvider{
+			TracerProvider: tp,
+			parent:         parent,
+		}
 	}
+	return tp
+}
 
-	// Then create the span exporter
-	exp, nerr := autoexport.NewSpanExporter(ctx)
-	if err != nil {
-		handleErr(nerr)
-		return
+// envParentContext extracts the trace context and baggage the provider was started
+// with, from the TRACEPARENT, TRACESTATE and BAGGAGE environment variables.
+func envParentContext(ctx context.Context) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+		"baggage":     os.Getenv("BAGGAGE"),
+	})
+}
+
+// parentTracerProvider starts the root spans as children of the parent context,
+// such that the provider traces show up under the trace of its caller.
+type parentTracerProvider struct {
+	trace.TracerProvider
+	parent context.Context
+}
+
+func (tp *parentTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &parentTracer{
+		Tracer: tp.TracerProvider.Tracer(name, opts...),
+		parent: tp.parent,
 	}
-	shutdownFuncs = append(shutdownFuncs, exp.Shutdown)
+}
 
-	out.TracerProvider = sdktrace.NewTracerProvider(
-		// We need to have the burden of a simple span processor as the process might be short-lived
-		// because a batch processor can not give enough time to export data...
-		sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(exp)),
-		sdktrace.WithResource(r),
-	)
+type parentTracer struct {
+	trace.Tracer
+	parent context.Context
+}
 
-	return
+func (t *parentTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, trace.SpanContextFromContext(t.parent))
+		if baggage.FromContext(ctx).Len() == 0 {
+			ctx = baggage.ContextWithBaggage(ctx, baggage.FromContext(t.parent))
+		}
+	}
+	return t.Tracer.Start(ctx, name, opts...)
 }
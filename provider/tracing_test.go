@@ -0,0 +1,43 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func Test_SetupOTelSDK_TraceParent(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx := context.Background()
+	out, err := provider.SetupOTelSDK(ctx, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer func() {
+		_ = out.Shutdown(ctx)
+	}()
+
+	// A root span continues the caller trace
+	ctx, span := out.TracerProvider.Tracer("test").Start(ctx, "root")
+	defer span.End()
+	sc := span.SpanContext()
+	if got := sc.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected root span in the caller trace, got trace %s", got)
+	}
+
+	// A child span remains under its actual parent
+	_, child := out.TracerProvider.Tracer("test").Start(ctx, "child")
+	defer child.End()
+	ro, ok := child.(sdktrace.ReadOnlySpan)
+	if !ok {
+		t.Fatalf("expected an SDK span, got %T", child)
+	}
+	if got := ro.Parent().SpanID(); got != sc.SpanID() {
+		t.Errorf("expected child span under %s, got %s", sc.SpanID(), got)
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*untilInFunction)(nil)
+
+func NewUntilInFunction() function.Function {
+	return &untilInFunction{}
+}
+
+type untilInFunction struct{}
+
+func (f *untilInFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "until_in"
+}
+
+func (f *untilInFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Compute an until date from a base.",
+		MarkdownDescription: "Computes the RFC3339 timestamp at a duration from a base timestamp, as expected by the `until` attribute of a challenge. This is convenient with `plantimestamp()` or the CTF end date.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "base",
+				MarkdownDescription: "The RFC3339 timestamp to start from, e.g. `2026-10-19T08:00:00Z`.",
+			},
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "The duration to add to base, e.g. `48h`. It could be negative, e.g. `-30m` to stop before the CTF end.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *untilInFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base, duration string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &base, &duration))
+	if resp.Error != nil {
+		return
+	}
+
+	t, err := time.Parse(time.RFC3339, base)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid RFC3339 timestamp: %s", err))
+		return
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid duration: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, t.Add(d).Format(time.RFC3339)))
+}
@@ -0,0 +1,56 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfdcm/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_UntilInFunction(t *testing.T) {
+	var tests = map[string]struct {
+		Base      string
+		Duration  string
+		Expected  string
+		ExpectErr bool
+	}{
+		"hours": {
+			Base:     "2026-10-19T08:00:00Z",
+			Duration: "48h",
+			Expected: "2026-10-21T08:00:00Z",
+		},
+		"negative": {
+			Base:     "2026-10-19T08:00:00+02:00",
+			Duration: "-30m",
+			Expected: "2026-10-19T07:30:00+02:00",
+		},
+		"invalid-base": {
+			Base:      "19/10/2026",
+			Duration:  "1h",
+			ExpectErr: true,
+		},
+		"invalid-duration": {
+			Base:      "2026-10-19T08:00:00Z",
+			Duration:  "2 days",
+			ExpectErr: true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			res, err := runFunction(t, provider.NewUntilInFunction(), types.StringValue(tt.Base), types.StringValue(tt.Duration))
+			if tt.ExpectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := res.(types.String).ValueString(); got != tt.Expected {
+				t.Errorf("expected %s, got %s", tt.Expected, got)
+			}
+		})
+	}
+}